@@ -0,0 +1,137 @@
+package archive
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+const currentVersion = 1
+
+// Archive is a local, per-provider copy of full order details, stored newest first (entries
+// are indexed by order code, which is unique within a provider).
+type Archive struct {
+	Version  int       `json:"version"`
+	Provider string    `json:"provider"`
+	SyncedAt time.Time `json:"synced_at,omitzero"`
+	// Partial marks an interrupted sync; the next sync walks the full history to close gaps.
+	Partial bool    `json:"partial,omitempty"`
+	Orders  []Entry `json:"orders"`
+
+	// byCode indexes Orders; rebuilt whenever it is out of step with the slice.
+	byCode map[string]int
+}
+
+type Entry struct {
+	Code     string         `json:"order_code"`
+	Time     time.Time      `json:"time,omitzero"`
+	SyncedAt time.Time      `json:"synced_at,omitzero"`
+	Detail   map[string]any `json:"detail"`
+}
+
+// PathFor returns the archive file for a provider below dir (the config file's directory, or
+// a profile's data directory).
+func PathFor(dir, provider string) string {
+	return filepath.Join(dir, "archive", provider+".json")
+}
+
+func New(provider string) Archive {
+	return Archive{Version: currentVersion, Provider: provider}
+}
+
+// Load returns an empty archive when the file does not exist yet.
+func Load(path, provider string) (Archive, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return New(provider), nil
+		}
+		return Archive{}, err
+	}
+	var a Archive
+	if err := json.Unmarshal(b, &a); err != nil {
+		return Archive{}, fmt.Errorf("archive %s: %w", path, err)
+	}
+	if a.Version == 0 {
+		a.Version = currentVersion
+	}
+	if a.Provider == "" {
+		a.Provider = provider
+	}
+	return a, nil
+}
+
+// Save writes the archive sorted newest first.
+func Save(path string, a Archive) error {
+	if a.Version == 0 {
+		a.Version = currentVersion
+	}
+	if a.Orders == nil {
+		a.Orders = []Entry{}
+	}
+	a.Sort()
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	b, err := json.MarshalIndent(a, "", "  ")
+	if err != nil {
+		return err
+	}
+	b = append(b, '\n')
+
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, b, 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+func (a *Archive) Get(code string) (Entry, bool) {
+	i, ok := a.index()[code]
+	if !ok {
+		return Entry{}, false
+	}
+	return a.Orders[i], true
+}
+
+func (a *Archive) Has(code string) bool {
+	_, ok := a.index()[code]
+	return ok
+}
+
+// Put inserts or replaces an entry. New entries are appended; Sort (done by Save) restores
+// the newest-first order, so a full sync doesn't re-sort per order.
+func (a *Archive) Put(e Entry) {
+	idx := a.index()
+	if i, ok := idx[e.Code]; ok {
+		a.Orders[i] = e
+		return
+	}
+	idx[e.Code] = len(a.Orders)
+	a.Orders = append(a.Orders, e)
+}
+
+// Sort orders the entries newest first (by time, then code).
+func (a *Archive) Sort() {
+	sort.SliceStable(a.Orders, func(i, j int) bool {
+		if !a.Orders[i].Time.Equal(a.Orders[j].Time) {
+			return a.Orders[i].Time.After(a.Orders[j].Time)
+		}
+		return a.Orders[i].Code > a.Orders[j].Code
+	})
+	a.byCode = nil
+}
+
+func (a *Archive) index() map[string]int {
+	if a.byCode == nil || len(a.byCode) != len(a.Orders) {
+		a.byCode = make(map[string]int, len(a.Orders))
+		for i, e := range a.Orders {
+			a.byCode[e.Code] = i
+		}
+	}
+	return a.byCode
+}
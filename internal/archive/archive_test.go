@@ -0,0 +1,68 @@
+package archive
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestLoad_Missing(t *testing.T) {
+	t.Parallel()
+	a, err := Load(filepath.Join(t.TempDir(), "nope.json"), "foodora")
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if a.Provider != "foodora" || a.Version != currentVersion || len(a.Orders) != 0 {
+		t.Fatalf("unexpected: %#v", a)
+	}
+}
+
+func TestPut_SortsAndReplaces(t *testing.T) {
+	t.Parallel()
+	a := New("foodora")
+	t0 := time.Date(2025, 12, 1, 12, 0, 0, 0, time.UTC)
+	a.Put(Entry{Code: "old", Time: t0})
+	a.Put(Entry{Code: "new", Time: t0.Add(time.Hour)})
+	a.Put(Entry{Code: "old", Time: t0, Detail: map[string]any{"x": "y"}})
+
+	// Put appends; sorting waits for Sort (or Save).
+	if len(a.Orders) != 2 || a.Orders[0].Code != "old" {
+		t.Fatalf("unexpected order: %#v", a.Orders)
+	}
+	a.Sort()
+	if a.Orders[0].Code != "new" || a.Orders[1].Code != "old" {
+		t.Fatalf("unexpected order: %#v", a.Orders)
+	}
+	a.Put(Entry{Code: "new", Time: t0.Add(time.Hour), Detail: map[string]any{"n": 1}})
+	if len(a.Orders) != 2 || a.Orders[0].Detail["n"] != 1 {
+		t.Fatalf("replace after sort: %#v", a.Orders)
+	}
+	e, ok := a.Get("old")
+	if !ok || e.Detail["x"] != "y" {
+		t.Fatalf("expected replaced entry, got %#v", e)
+	}
+	if a.Has("missing") {
+		t.Fatalf("expected missing")
+	}
+}
+
+func TestSaveLoad_RoundTrip(t *testing.T) {
+	t.Parallel()
+	path := PathFor(t.TempDir(), "foodora")
+
+	a := New("foodora")
+	a.SyncedAt = time.Date(2025, 12, 20, 0, 0, 0, 0, time.UTC)
+	a.Put(Entry{Code: "X", Detail: map[string]any{"order_code": "X", "total_value": 1.5}})
+	a.Put(Entry{Code: "Y", Time: a.SyncedAt})
+	if err := Save(path, a); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	got, err := Load(path, "foodora")
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if !got.SyncedAt.Equal(a.SyncedAt) || len(got.Orders) != 2 || got.Orders[0].Code != "Y" || got.Orders[1].Detail["total_value"] != 1.5 {
+		t.Fatalf("unexpected: %#v", got)
+	}
+}
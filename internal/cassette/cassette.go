@@ -0,0 +1,274 @@
+// Package cassette records HTTP interactions to a directory and replays them offline.
+//
+// Every round trip becomes one JSON file (00001-GET-host.json, ...) with credentials, tokens
+// and personal data redacted (see internal/redact), so cassettes can be attached to bug
+// reports and turned into regression tests. Replay matches requests by method and URL
+// (sensitive query values masked) and hands out the recorded responses for each in order;
+// the last one repeats once they run out, which keeps polling loops going. Redaction happens
+// when recording, so replayed bodies carry the masked values, not what the server sent.
+package cassette
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/steipete/ordercli/internal/redact"
+)
+
+// Interaction is one recorded round trip.
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+type Request struct {
+	Method string      `json:"method"`
+	URL    string      `json:"url"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body,omitempty"`
+}
+
+type Response struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+// Recorder is an http.RoundTripper that passes requests to Base and writes each round trip
+// to Dir. Recording failures fail the request: a silently incomplete cassette is worse.
+type Recorder struct {
+	Dir  string
+	Base http.RoundTripper
+
+	mu  sync.Mutex
+	seq int
+}
+
+// NewRecorder creates dir if needed; numbering continues after the highest number already in
+// it, so recording into a cassette with gaps never reuses a name.
+func NewRecorder(dir string, base http.RoundTripper) (*Recorder, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, err
+	}
+	existing, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	seq := 0
+	for _, f := range existing {
+		prefix, _, _ := strings.Cut(filepath.Base(f), "-")
+		if n, err := strconv.Atoi(prefix); err == nil {
+			seq = max(seq, n)
+		}
+	}
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &Recorder{Dir: dir, Base: base, seq: seq}, nil
+}
+
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	reqBody, err := requestBody(req)
+	if err != nil {
+		return nil, err
+	}
+	if reqBody != nil {
+		req = req.Clone(req.Context())
+		req.Body = io.NopCloser(bytes.NewReader(reqBody))
+	}
+
+	resp, err := r.Base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	respBody, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	it := Interaction{
+		Request: Request{
+			Method: req.Method,
+			URL:    normalizeURL(req.URL),
+			Header: redact.Header(req.Header),
+			Body:   redactBody(req.Header, reqBody),
+		},
+		Response: Response{
+			StatusCode: resp.StatusCode,
+			Header:     redact.Header(resp.Header),
+			Body:       redactBody(resp.Header, respBody),
+		},
+	}
+	if err := r.write(it, req.URL.Hostname()); err != nil {
+		return nil, fmt.Errorf("cassette: %w", err)
+	}
+	return resp, nil
+}
+
+func (r *Recorder) write(it Interaction, host string) error {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false) // keep URLs and HTML bodies readable
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(it); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.seq++
+	name := fmt.Sprintf("%05d-%s-%s.json", r.seq, it.Request.Method, host)
+	// O_EXCL: another recorder writing into the same directory must not clobber a recording.
+	f, err := os.OpenFile(filepath.Join(r.Dir, name), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(buf.Bytes()); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// Player is an http.RoundTripper serving recorded responses; it never touches the network.
+type Player struct {
+	mu    sync.Mutex
+	byKey map[string][]Interaction
+	next  map[string]int
+}
+
+// Load reads all interactions in dir, in file name order.
+func Load(dir string) (*Player, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("cassette: no recordings in %s", dir)
+	}
+	sort.Strings(files)
+
+	p := &Player{byKey: map[string][]Interaction{}, next: map[string]int{}}
+	for _, f := range files {
+		b, err := os.ReadFile(f)
+		if err != nil {
+			return nil, err
+		}
+		var it Interaction
+		if err := json.Unmarshal(b, &it); err != nil {
+			return nil, fmt.Errorf("cassette: %s: %w", filepath.Base(f), err)
+		}
+		u, err := url.Parse(it.Request.URL)
+		if err != nil {
+			return nil, fmt.Errorf("cassette: %s: %w", filepath.Base(f), err)
+		}
+		k := Key(it.Request.Method, u)
+		p.byKey[k] = append(p.byKey[k], it)
+	}
+	return p, nil
+}
+
+func (p *Player) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		_ = req.Body.Close()
+	}
+	k := Key(req.Method, req.URL)
+
+	p.mu.Lock()
+	its := p.byKey[k]
+	i := p.next[k]
+	if i < len(its) {
+		p.next[k] = i + 1
+	}
+	p.mu.Unlock()
+
+	if len(its) == 0 {
+		return nil, fmt.Errorf("cassette: no recorded response for %s", k)
+	}
+	it := its[min(i, len(its)-1)]
+	body := []byte(it.Response.Body)
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", it.Response.StatusCode, http.StatusText(it.Response.StatusCode)),
+		StatusCode:    it.Response.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        it.Response.Header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
+
+// Key identifies a request for replay: method plus URL with a lower-cased host, sorted query
+// and sensitive query values masked (the same way they are recorded).
+func Key(method string, u *url.URL) string {
+	return method + " " + normalizeURL(u)
+}
+
+func normalizeURL(u *url.URL) string {
+	c := *u
+	c.Host = strings.ToLower(c.Host)
+	c.Fragment = ""
+	c.RawQuery = redact.Form(c.RawQuery)
+	return c.String()
+}
+
+// requestBody reads the request body without consuming the one the transport will send.
+func requestBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+	if req.GetBody != nil {
+		rc, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		defer rc.Close()
+		return io.ReadAll(rc)
+	}
+	defer req.Body.Close()
+	return io.ReadAll(req.Body)
+}
+
+func redactBody(h http.Header, b []byte) string {
+	if len(b) == 0 {
+		return ""
+	}
+	if strings.Contains(h.Get("Content-Type"), "x-www-form-urlencoded") {
+		return redact.Form(string(b))
+	}
+	out := redact.JSON(b)
+	// Keep the original bytes (key order, whitespace) when there was nothing to mask, so
+	// replay is byte-for-byte what the server sent.
+	if out == string(b) || out == compact(b) {
+		return string(b)
+	}
+	return out
+}
+
+// compact is b re-encoded the way redact.JSON encodes it, or "" when b isn't JSON.
+func compact(b []byte) string {
+	var v any
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	if err := dec.Decode(&v); err != nil || dec.More() {
+		return ""
+	}
+	c, err := json.Marshal(v)
+	if err != nil {
+		return ""
+	}
+	return string(c)
+}
@@ -0,0 +1,146 @@
+package cassette
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRecordAndReplay(t *testing.T) {
+	t.Parallel()
+
+	var n int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n++
+		switch r.URL.Path {
+		case "/oauth2/token":
+			w.Header().Set("Content-Type", "application/json")
+			w.Header().Set("Set-Cookie", "sid=secret-cookie")
+			_, _ = w.Write([]byte(`{"access_token":"secret-at","expires_in":3600}`))
+		default:
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"page":` + r.URL.Query().Get("page") + `,"n":` + string(rune('0'+n)) + `}`))
+		}
+	}))
+	defer srv.Close()
+
+	dir := filepath.Join(t.TempDir(), "cassette")
+	rec, err := NewRecorder(dir, nil)
+	if err != nil {
+		t.Fatalf("NewRecorder: %v", err)
+	}
+	cl := &http.Client{Transport: rec}
+
+	req, _ := http.NewRequest(http.MethodPost, srv.URL+"/oauth2/token", strings.NewReader("grant_type=password&password=hunter2"))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Authorization", "Basic c2VjcmV0")
+	live := []string{do(t, cl, req)}
+	for _, page := range []string{"1", "1", "2"} {
+		req, _ := http.NewRequest(http.MethodGet, srv.URL+"/orders?page="+page+"&access_token=secret-qt", nil)
+		live = append(live, do(t, cl, req))
+	}
+
+	files, _ := filepath.Glob(filepath.Join(dir, "*.json"))
+	if len(files) != 4 || !strings.HasSuffix(files[0], "00001-POST-127.0.0.1.json") {
+		t.Fatalf("files=%v", files)
+	}
+	for _, f := range files {
+		b, _ := os.ReadFile(f)
+		for _, leak := range []string{"hunter2", "c2VjcmV0", "secret-at", "secret-cookie", "secret-qt"} {
+			if strings.Contains(string(b), leak) {
+				t.Fatalf("%s leaks %q:\n%s", filepath.Base(f), leak, b)
+			}
+		}
+	}
+
+	p, err := Load(dir)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	cl = &http.Client{Transport: p}
+	req, _ = http.NewRequest(http.MethodPost, srv.URL+"/oauth2/token", strings.NewReader("grant_type=password&password=other"))
+	if got := do(t, cl, req); got != `{"access_token":"***","expires_in":3600}` {
+		t.Fatalf("token replay=%s", got)
+	}
+	// Same URL twice: the recorded responses come back in order (the query's token value
+	// doesn't matter, it was masked), then the last one repeats.
+	var replayed []string
+	for _, page := range []string{"1", "1", "1", "2"} {
+		req, _ := http.NewRequest(http.MethodGet, srv.URL+"/orders?access_token=other&page="+page, nil)
+		replayed = append(replayed, do(t, cl, req))
+	}
+	if replayed[0] != live[1] || replayed[1] != live[2] || replayed[2] != live[2] || replayed[3] != live[3] {
+		t.Fatalf("live=%v replayed=%v", live, replayed)
+	}
+
+	req, _ = http.NewRequest(http.MethodGet, srv.URL+"/unknown", nil)
+	if _, err := cl.Do(req); err == nil || !strings.Contains(err.Error(), "no recorded response for GET") {
+		t.Fatalf("expected miss error, got %v", err)
+	}
+	if n != 4 {
+		t.Fatalf("replay hit the network: %d requests", n)
+	}
+}
+
+func TestRecorder_ContinuesAfterHighestNumber(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(r.URL.Path))
+	}))
+	defer srv.Close()
+
+	dir := t.TempDir()
+	record := func(path string) {
+		t.Helper()
+		rec, err := NewRecorder(dir, nil)
+		if err != nil {
+			t.Fatalf("NewRecorder: %v", err)
+		}
+		req, _ := http.NewRequest(http.MethodGet, srv.URL+path, nil)
+		do(t, &http.Client{Transport: rec}, req)
+	}
+	record("/a")
+	record("/b")
+	record("/c")
+	// With 00001 gone, two files are left; the next one must be 00004, not a new 00003.
+	first, _ := filepath.Glob(filepath.Join(dir, "00001-*.json"))
+	if len(first) != 1 {
+		t.Fatalf("files=%v", first)
+	}
+	if err := os.Remove(first[0]); err != nil {
+		t.Fatalf("remove: %v", err)
+	}
+	record("/d")
+
+	files, _ := filepath.Glob(filepath.Join(dir, "*.json"))
+	if len(files) != 3 || !strings.HasPrefix(filepath.Base(files[2]), "00004-") {
+		t.Fatalf("files=%v", files)
+	}
+	if b, _ := os.ReadFile(files[1]); !strings.Contains(string(b), `"body": "/c"`) {
+		t.Fatalf("00003 overwritten:\n%s", b)
+	}
+}
+
+func TestLoad_Empty(t *testing.T) {
+	t.Parallel()
+
+	if _, err := Load(t.TempDir()); err == nil {
+		t.Fatalf("expected error for an empty cassette")
+	}
+}
+
+func do(t *testing.T, cl *http.Client, req *http.Request) string {
+	t.Helper()
+	resp, err := cl.Do(req)
+	if err != nil {
+		t.Fatalf("%s %s: %v", req.Method, req.URL, err)
+	}
+	defer resp.Body.Close()
+	b, _ := io.ReadAll(resp.Body)
+	return string(b)
+}
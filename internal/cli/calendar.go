@@ -0,0 +1,209 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steipete/ordercli/internal/ical"
+	"github.com/steipete/ordercli/internal/provider"
+)
+
+const calendarName = "ordercli orders"
+
+func newCalendarCmd(st *state) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "calendar",
+		Short: "iCalendar feeds of past and active orders",
+	}
+	cmd.AddCommand(newCalendarExportCmd(st))
+	return cmd
+}
+
+func newCalendarExportCmd(st *state) *cobra.Command {
+	var limit int
+	var pageSize int
+	var only []string
+	var offline bool
+	var active bool
+	var duration time.Duration
+	var output string
+
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Write an .ics calendar with one event per delivered order (plus ETAs of active orders)",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var orders []provider.Order
+			if offline {
+				past, err := archivedOrders(st, limit)
+				if err != nil {
+					return err
+				}
+				orders = past
+			} else {
+				ps, err := openProviders(st, only, cmd.ErrOrStderr())
+				if err != nil {
+					return err
+				}
+				past, err := historyFromProviders(cmd, ps, limit, pageSize)
+				if err != nil {
+					return err
+				}
+				orders = past
+				if active {
+					results := fanOut(cmd.Context(), ps, func(ctx context.Context, p provider.Provider) ([]provider.Order, error) {
+						page, err := p.ActiveOrders(ctx)
+						return withETAs(ctx, p, page.Orders), err
+					})
+					current, err := mergeResults(cmd.ErrOrStderr(), results)
+					if err != nil {
+						return err
+					}
+					orders = append(current, orders...)
+				}
+			}
+
+			now := time.Now()
+			cal := ical.Calendar{Name: calendarName, Now: now}
+			seen := map[string]bool{}
+			for _, o := range orders {
+				// History can also list an order that is still active; the live entry wins.
+				uid := ical.UID(o.Provider, o.ID)
+				if seen[uid] {
+					continue
+				}
+				seen[uid] = true
+				if !o.Active && o.Cancelled {
+					continue // only delivered orders get an event
+				}
+				if e, ok := orderEvent(o, duration, now); ok {
+					cal.Events = append(cal.Events, e)
+				}
+			}
+			return writeOutput(cmd.OutOrStdout(), output, func(w io.Writer) error {
+				return ical.Write(w, cal)
+			})
+		},
+	}
+
+	cmd.Flags().IntVar(&limit, "limit", 100, "max past orders per provider")
+	cmd.Flags().IntVar(&pageSize, "page-size", 20, "page size per provider request")
+	cmd.Flags().StringSliceVar(&only, "provider", nil, "only query these providers (repeatable; default: all logged-in)")
+	cmd.Flags().BoolVar(&offline, "offline", false, "read past orders from the local archive (no network, no active orders)")
+	cmd.Flags().BoolVar(&active, "active", true, "include active orders at their ETA")
+	cmd.Flags().DurationVar(&duration, "duration", 15*time.Minute, "event length")
+	cmd.Flags().StringVarP(&output, "output", "o", "", "write to file instead of stdout")
+	return cmd
+}
+
+// orderEvent places past orders at their delivery time and active orders at their ETA
+// (or now, when the provider has no estimate). Cancelled orders are marked CANCELLED, so a
+// watched order's ETA event is withdrawn from subscribed calendars.
+func orderEvent(o provider.Order, duration time.Duration, now time.Time) (ical.Event, bool) {
+	vendor := o.Vendor.Name
+	if vendor == "" {
+		vendor = o.Provider + " order"
+	}
+	e := ical.Event{
+		UID:     ical.UID(o.Provider, o.ID),
+		Summary: vendor,
+		Status:  "CONFIRMED",
+	}
+	if o.Active {
+		e.Start = o.ETA
+		if e.Start.IsZero() {
+			e.Start = now
+		}
+		e.Summary = "ETA: " + vendor
+		e.Status = "TENTATIVE"
+	} else {
+		if o.Time.IsZero() {
+			return ical.Event{}, false
+		}
+		e.Start = o.Time
+	}
+	if o.Cancelled {
+		e.Status = "CANCELLED"
+	}
+	if duration > 0 {
+		e.End = e.Start.Add(duration)
+	}
+
+	desc := []string{fmt.Sprintf("%s order %s", o.Provider, o.ID)}
+	if o.Status != "" {
+		desc = append(desc, "status: "+o.Status)
+	}
+	if !o.Total.IsZero() {
+		desc = append(desc, "total: "+o.Total.String())
+	}
+	e.Description = strings.Join(desc, "\n")
+	return e, true
+}
+
+// withETAs fills missing ETAs of active orders for providers that can look them up.
+// Lookup errors are ignored; the order keeps a zero ETA.
+func withETAs(ctx context.Context, p provider.Provider, orders []provider.Order) []provider.Order {
+	ep, ok := p.(provider.ETAProvider)
+	if !ok {
+		return orders
+	}
+	for i, o := range orders {
+		if !o.Active || !o.ETA.IsZero() {
+			continue
+		}
+		if eta, err := ep.OrderETA(ctx, o.ID); err == nil {
+			orders[i].ETA = eta
+		}
+	}
+	return orders
+}
+
+// watchCalendar remembers every order seen during `orders --watch --ics`; orders that drop
+// out of the active list are kept as arrived at the time they disappeared.
+type watchCalendar struct {
+	keys   []string
+	orders map[string]provider.Order
+}
+
+func newWatchCalendar() *watchCalendar {
+	return &watchCalendar{orders: map[string]provider.Order{}}
+}
+
+func (c *watchCalendar) update(active []provider.Order, now time.Time) {
+	current := map[string]bool{}
+	for _, o := range active {
+		k := ical.UID(o.Provider, o.ID)
+		current[k] = true
+		if _, ok := c.orders[k]; !ok {
+			c.keys = append(c.keys, k)
+		}
+		if o.Time.IsZero() && !o.Active {
+			o.Time = now
+		}
+		c.orders[k] = o
+	}
+	for _, k := range c.keys {
+		o := c.orders[k]
+		if current[k] || !o.Active {
+			continue
+		}
+		o.Active = false
+		o.Time = now
+		c.orders[k] = o
+	}
+}
+
+func (c *watchCalendar) write(w io.Writer) error {
+	now := time.Now()
+	cal := ical.Calendar{Name: calendarName, Now: now}
+	for _, k := range c.keys {
+		if e, ok := orderEvent(c.orders[k], 0, now); ok {
+			cal.Events = append(cal.Events, e)
+		}
+	}
+	return ical.Write(w, cal)
+}
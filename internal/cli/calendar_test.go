@@ -0,0 +1,130 @@
+package cli
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steipete/ordercli/internal/provider"
+)
+
+func TestCalendarExport(t *testing.T) {
+	cfgPath := filepath.Join(t.TempDir(), "config.json")
+	srv := newFoodoraTestServer(t)
+	defer srv.Close()
+	writeLoggedInFoodoraConfig(t, cfgPath, srv.URL+"/")
+	setEnv(t, "DELIVEROO_BEARER_TOKEN", "")
+
+	out, _, err := runCLI(cfgPath, []string{"calendar", "export"}, "")
+	if err != nil {
+		t.Fatalf("calendar export: %v", err)
+	}
+	for _, want := range []string{
+		"UID:foodora-HIST-1@ordercli",
+		"DTSTART:20251220T000000Z",
+		"SUMMARY:Test Vendor",
+		"UID:foodora-OC-1@ordercli",
+		"SUMMARY:ETA: Vendor",
+		"STATUS:TENTATIVE",
+	} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("missing %q in:\n%s", want, out)
+		}
+	}
+
+	out, _, err = runCLI(cfgPath, []string{"calendar", "export", "--active=false"}, "")
+	if err != nil {
+		t.Fatalf("calendar export --active=false: %v", err)
+	}
+	if strings.Contains(out, "OC-1") {
+		t.Fatalf("unexpected active order:\n%s", out)
+	}
+}
+
+func TestFoodoraOrders_ICS(t *testing.T) {
+	dir := t.TempDir()
+	cfgPath := filepath.Join(dir, "config.json")
+	srv := newFoodoraTestServer(t)
+	defer srv.Close()
+	writeLoggedInFoodoraConfig(t, cfgPath, srv.URL+"/")
+
+	icsPath := filepath.Join(dir, "orders.ics")
+	out, _, err := runCLI(cfgPath, []string{"foodora", "orders", "--ics", icsPath}, "")
+	if err != nil {
+		t.Fatalf("orders --ics: %v", err)
+	}
+	if !strings.Contains(out, "OC-1") {
+		t.Fatalf("unexpected out=%s", out)
+	}
+	b, err := os.ReadFile(icsPath)
+	if err != nil {
+		t.Fatalf("read ics: %v", err)
+	}
+	if !strings.Contains(string(b), "UID:foodora-OC-1@ordercli") {
+		t.Fatalf("unexpected ics:\n%s", b)
+	}
+}
+
+func TestWatchCalendar_KeepsArrivedOrders(t *testing.T) {
+	t.Parallel()
+	c := newWatchCalendar()
+	t0 := time.Date(2025, 12, 20, 18, 0, 0, 0, time.UTC)
+	eta := t0.Add(25 * time.Minute)
+	c.update([]provider.Order{{Provider: "foodora", ID: "A", Vendor: provider.Vendor{Name: "V"}, ETA: eta, Active: true}}, t0)
+	c.update(nil, t0.Add(30*time.Minute))
+
+	var buf bytes.Buffer
+	if err := c.write(&buf); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	out := buf.String()
+	if !strings.Contains(out, "DTSTART:20251220T183000Z") || !strings.Contains(out, "SUMMARY:V\r\n") || !strings.Contains(out, "STATUS:CONFIRMED") {
+		t.Fatalf("unexpected ics:\n%s", out)
+	}
+}
+
+func TestCalendarExport_SkipsCancelled(t *testing.T) {
+	cfgPath := filepath.Join(t.TempDir(), "config.json")
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"count":2,"orders":[` +
+			`{"id":"ok","status":"delivered","submitted_at":"2025-12-20T18:00:00Z","delivered_at":"2025-12-20T18:30:00Z","restaurant":{"name":"R"}},` +
+			`{"id":"gone","status":"cancelled","submitted_at":"2025-12-19T18:00:00Z","restaurant":{"name":"R"}}]}`))
+	}))
+	defer srv.Close()
+
+	setEnv(t, "DELIVEROO_BEARER_TOKEN", "tok")
+	if _, _, err := runCLI(cfgPath, []string{"deliveroo", "config", "set", "--base-url", srv.URL}, ""); err != nil {
+		t.Fatalf("config set: %v", err)
+	}
+	out, _, err := runCLI(cfgPath, []string{"calendar", "export", "--provider", "deliveroo", "--active=false"}, "")
+	if err != nil {
+		t.Fatalf("calendar export: %v", err)
+	}
+	if !strings.Contains(out, "UID:deliveroo-ok@ordercli") || strings.Contains(out, "gone") {
+		t.Fatalf("unexpected ics:\n%s", out)
+	}
+}
+
+func TestWatchCalendar_CancelsOrders(t *testing.T) {
+	t.Parallel()
+	c := newWatchCalendar()
+	t0 := time.Date(2025, 12, 20, 18, 0, 0, 0, time.UTC)
+	o := provider.Order{Provider: "foodora", ID: "A", Vendor: provider.Vendor{Name: "V"}, ETA: t0.Add(25 * time.Minute), Active: true}
+	c.update([]provider.Order{o}, t0)
+	o.Active, o.Cancelled = false, true
+	c.update([]provider.Order{o}, t0.Add(10*time.Minute))
+
+	var buf bytes.Buffer
+	if err := c.write(&buf); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if out := buf.String(); !strings.Contains(out, "STATUS:CANCELLED") || strings.Contains(out, "STATUS:CONFIRMED") {
+		t.Fatalf("unexpected ics:\n%s", out)
+	}
+}
@@ -24,6 +24,9 @@ func newConfigShowCmd(st *state) *cobra.Command {
 		Short: "Print current config (redacts tokens)",
 		Run: func(cmd *cobra.Command, args []string) {
 			cfg := st.foodora()
+			if st.profile != "" {
+				fmt.Fprintf(cmd.OutOrStdout(), "profile=%s\n", st.profile)
+			}
 			fmt.Fprintf(cmd.OutOrStdout(), "base_url=%s\n", cfg.BaseURL)
 			fmt.Fprintf(cmd.OutOrStdout(), "global_entity_id=%s\n", cfg.GlobalEntityID)
 			fmt.Fprintf(cmd.OutOrStdout(), "target_country_iso=%s\n", cfg.TargetCountryISO)
@@ -46,6 +49,9 @@ func newConfigShowCmd(st *state) *cobra.Command {
 			if len(cfg.CookiesByHost) > 0 {
 				fmt.Fprintf(cmd.OutOrStdout(), "cookies_by_host=*** (%d)\n", len(cfg.CookiesByHost))
 			}
+			if cfg.MCPAllowReorder {
+				fmt.Fprintf(cmd.OutOrStdout(), "mcp_allow_reorder=true\n")
+			}
 			if cfg.PendingMfaToken != "" {
 				fmt.Fprintf(cmd.OutOrStdout(), "pending_mfa=*** (%s, %s)\n", cfg.PendingMfaChannel, cfg.PendingMfaEmail)
 			}
@@ -58,12 +64,18 @@ func newConfigSetCmd(st *state) *cobra.Command {
 	var baseURL string
 	var globalEntityID string
 	var targetISO string
+	var mcpAllowReorder bool
 
 	cmd := &cobra.Command{
 		Use:   "set",
 		Short: "Update base URL / country",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			cfg := st.foodora()
+			mcpChanged := cmd.Flags().Changed("mcp-allow-reorder")
+			if mcpChanged {
+				cfg.MCPAllowReorder = mcpAllowReorder
+				st.markDirty()
+			}
 			if country != "" {
 				country = strings.ToUpper(country)
 				p, ok := findPreset(country)
@@ -78,7 +90,10 @@ func newConfigSetCmd(st *state) *cobra.Command {
 			}
 
 			if baseURL == "" && globalEntityID == "" && targetISO == "" {
-				return errors.New("nothing to set (use --country or --base-url/--global-entity-id/--target-iso)")
+				if mcpChanged {
+					return nil
+				}
+				return errors.New("nothing to set (use --country, --base-url/--global-entity-id/--target-iso or --mcp-allow-reorder)")
 			}
 			if baseURL != "" {
 				cfg.BaseURL = baseURL
@@ -98,5 +113,6 @@ func newConfigSetCmd(st *state) *cobra.Command {
 	cmd.Flags().StringVar(&baseURL, "base-url", "", "API base URL (e.g. https://hu.fd-api.com/api/v5/)")
 	cmd.Flags().StringVar(&globalEntityID, "global-entity-id", "", "X-Global-Entity-ID (e.g. NP_HU)")
 	cmd.Flags().StringVar(&targetISO, "target-iso", "", "X-Target-Country-Code-ISO (e.g. HU)")
+	cmd.Flags().BoolVar(&mcpAllowReorder, "mcp-allow-reorder", false, "expose the reorder_confirm tool in ordercli mcp (adds to cart)")
 	return cmd
 }
@@ -22,7 +22,7 @@ func newCookiesCmd(st *state) *cobra.Command {
 }
 
 func newCookiesChromeCmd(st *state) *cobra.Command {
-	var profile string
+	var chromeProfile string
 	var cookiePath string
 	var timeout time.Duration
 	var filterNames []string
@@ -55,7 +55,7 @@ func newCookiesChromeCmd(st *state) *cobra.Command {
 			cacheDir := filepath.Join(filepath.Dir(st.configPath), "chrome-cookies")
 			res, err := chromeLoadCookieHeader(cmd.Context(), chromecookies.Options{
 				TargetURL:          targetURL,
-				ChromeProfile:      profile,
+				ChromeProfile:      chromeProfile,
 				ExplicitCookiePath: cookiePath,
 				FilterNames:        filterNames,
 				Timeout:            timeout,
@@ -66,7 +66,7 @@ func newCookiesChromeCmd(st *state) *cobra.Command {
 				return err
 			}
 			if strings.TrimSpace(res.CookieHeader) == "" {
-				return errors.New("no cookies found (are you logged in in Chrome? try --profile \"Default\" / \"Profile 1\" or --cookie-path)")
+				return errors.New("no cookies found (are you logged in in Chrome? try --chrome-profile \"Default\" / \"Profile 1\" or --cookie-path)")
 			}
 
 			if cfg.CookiesByHost == nil {
@@ -80,8 +80,8 @@ func newCookiesChromeCmd(st *state) *cobra.Command {
 		},
 	}
 
-	cmd.Flags().StringVar(&profile, "profile", "", "Chrome profile name (Default, Profile 1, ...) or path to profile dir")
-	cmd.Flags().StringVar(&cookiePath, "cookie-path", "", "explicit Cookies DB path or profile dir (overrides --profile)")
+	cmd.Flags().StringVar(&chromeProfile, "chrome-profile", "", "Chrome profile name (Default, Profile 1, ...) or path to profile dir")
+	cmd.Flags().StringVar(&cookiePath, "cookie-path", "", "explicit Cookies DB path or profile dir (overrides --chrome-profile)")
 	cmd.Flags().StringVar(&sourceURL, "url", "", "URL to load cookies from (default: base_url origin)")
 	cmd.Flags().StringSliceVar(&filterNames, "filter-name", nil, "cookie name to include (repeatable; default: all for target URL)")
 	cmd.Flags().DurationVar(&timeout, "timeout", 5*time.Second, "cookie read timeout (keychain prompts may need longer)")
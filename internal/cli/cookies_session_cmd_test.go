@@ -81,3 +81,43 @@ func TestSessionChromeCmd_ImportsTokens(t *testing.T) {
 		t.Fatalf("unexpected out: %s", out)
 	}
 }
+
+func TestSessionChromeCmd_ConfigProfile(t *testing.T) {
+	cfgPath := filepath.Join(t.TempDir(), "config.json")
+	setEnv(t, profileEnv, "")
+
+	if _, _, err := runCLI(cfgPath, []string{"profile", "add", "work", "--country", "AT"}, ""); err != nil {
+		t.Fatalf("profile add: %v", err)
+	}
+
+	var gotChrome string
+	orig := chromeLoadCookieHeader
+	defer func() { chromeLoadCookieHeader = orig }()
+	chromeLoadCookieHeader = func(ctx context.Context, opts chromecookies.Options) (chromecookies.Result, error) {
+		gotChrome = opts.ChromeProfile
+		return chromecookies.Result{CookieHeader: "refresh_token=ref; device_token=dev", CookieCount: 2}, nil
+	}
+
+	// --profile picks the config profile, --chrome-profile the browser profile.
+	if _, _, err := runCLI(cfgPath, []string{"--profile", "nosuch", "foodora", "session", "chrome", "--url", "https://www.foodora.at/"}, ""); err == nil || !strings.Contains(err.Error(), `unknown profile "nosuch"`) {
+		t.Fatalf("want unknown profile error, got %v", err)
+	}
+	if gotChrome != "" {
+		t.Fatalf("imported despite the unknown profile")
+	}
+	if _, _, err := runCLI(cfgPath, []string{"--profile", "work", "foodora", "session", "chrome", "--url", "https://www.foodora.at/", "--chrome-profile", "Profile 1"}, ""); err != nil {
+		t.Fatalf("session chrome: %v", err)
+	}
+	if gotChrome != "Profile 1" {
+		t.Fatalf("chrome profile=%q", gotChrome)
+	}
+
+	out, _, err := runCLI(cfgPath, []string{"--profile", "work", "foodora", "config", "show"}, "")
+	if err != nil || !strings.Contains(out, "refresh_token=***") {
+		t.Fatalf("work: err=%v out=%s", err, out)
+	}
+	out, _, err = runCLI(cfgPath, []string{"foodora", "config", "show"}, "")
+	if err != nil || strings.Contains(out, "refresh_token=***") {
+		t.Fatalf("default got the session: err=%v out=%s", err, out)
+	}
+}
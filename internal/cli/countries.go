@@ -2,6 +2,7 @@ package cli
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/spf13/cobra"
 )
@@ -11,13 +12,14 @@ type countryPreset struct {
 	BaseURL        string
 	GlobalEntityID string
 	TargetISO      string
+	Currency       string
 }
 
 var presets = []countryPreset{
-	{Code: "HU", BaseURL: "https://hu.fd-api.com/api/v5/", GlobalEntityID: "NP_HU", TargetISO: "HU"},
-	{Code: "SK", BaseURL: "https://sk.fd-api.com/api/v5/", GlobalEntityID: "FP_SK", TargetISO: "SK"},
-	{Code: "DL", BaseURL: "https://dl.fd-api.com/api/v5/", GlobalEntityID: "FP_DE", TargetISO: "DE"},
-	{Code: "AT", BaseURL: "https://mj.fd-api.com/api/v5/", GlobalEntityID: "MJM_AT", TargetISO: "AT"},
+	{Code: "HU", BaseURL: "https://hu.fd-api.com/api/v5/", GlobalEntityID: "NP_HU", TargetISO: "HU", Currency: "HUF"},
+	{Code: "SK", BaseURL: "https://sk.fd-api.com/api/v5/", GlobalEntityID: "FP_SK", TargetISO: "SK", Currency: "EUR"},
+	{Code: "DL", BaseURL: "https://dl.fd-api.com/api/v5/", GlobalEntityID: "FP_DE", TargetISO: "DE", Currency: "EUR"},
+	{Code: "AT", BaseURL: "https://mj.fd-api.com/api/v5/", GlobalEntityID: "MJM_AT", TargetISO: "AT", Currency: "EUR"},
 }
 
 func newCountriesCmd(st *state) *cobra.Command {
@@ -40,3 +42,13 @@ func findPreset(code string) (countryPreset, bool) {
 	}
 	return countryPreset{}, false
 }
+
+// countryCurrency is the currency of a bundled preset's country ("" when unknown).
+func countryCurrency(iso string) string {
+	for _, p := range presets {
+		if strings.EqualFold(p.TargetISO, iso) {
+			return p.Currency
+		}
+	}
+	return ""
+}
@@ -0,0 +1,91 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/steipete/ordercli/internal/provider"
+)
+
+// dateWindow keeps orders placed in [since, until); a zero bound is open.
+type dateWindow struct {
+	since, until time.Time
+}
+
+// parseDateWindow parses both bounds with parseDateArg; errors name them with prefix + "since"
+// / "until" ("--" for flags).
+func parseDateWindow(since, until, prefix string) (dateWindow, error) {
+	var w dateWindow
+	var err error
+	if w.since, err = parseDateArg(since); err != nil {
+		return dateWindow{}, fmt.Errorf("%ssince: %w", prefix, err)
+	}
+	if w.until, err = parseDateArg(until); err != nil {
+		return dateWindow{}, fmt.Errorf("%suntil: %w", prefix, err)
+	}
+	return w, nil
+}
+
+// match reports whether o is in the window, by submission time where the provider reports it.
+// History is newest first: once past since, nothing older can match, so stop is set.
+// Orders without a time are kept.
+func (w dateWindow) match(o provider.Order) (keep, stop bool) {
+	t := o.Submitted
+	if t.IsZero() {
+		t = o.Time
+	}
+	if t.IsZero() {
+		return true, false
+	}
+	if !w.since.IsZero() && t.Before(w.since) {
+		return false, true
+	}
+	if !w.until.IsZero() && !t.Before(w.until) {
+		return false, false
+	}
+	return true, false
+}
+
+// walkHistoryWindow walks at most scan orders of p's history from offset and calls fn for
+// those in w, until fn accepted limit of them or the walk left the window. It returns the
+// number of orders scanned.
+func walkHistoryWindow(ctx context.Context, p provider.Provider, w dateWindow, offset, scan, pageSize, limit int, fn func(provider.Order) bool) (int, error) {
+	errDone := errors.New("done")
+	accepted := 0
+	n, err := provider.WalkHistoryFrom(ctx, p, offset, scan, pageSize, func(o provider.Order) error {
+		keep, stop := w.match(o)
+		if stop {
+			return errDone
+		}
+		if keep && fn(o) {
+			accepted++
+		}
+		if accepted >= limit {
+			return errDone
+		}
+		return nil
+	})
+	if errors.Is(err, errDone) {
+		err = nil
+	}
+	return n, err
+}
+
+// parseDateArg accepts YYYY-MM-DD (local midnight) or RFC 3339; empty means no bound.
+func parseDateArg(s string) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.ParseInLocation("2006-01-02", s, time.Local); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("want YYYY-MM-DD or RFC 3339, got %q", s)
+	}
+	return t, nil
+}
@@ -1,11 +1,22 @@
 package cli
 
 import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
+
+	"github.com/steipete/ordercli/internal/chromecookies"
+	"github.com/steipete/ordercli/internal/config"
+	"github.com/steipete/ordercli/internal/deliveroo"
+	"github.com/steipete/ordercli/internal/provider"
 )
 
 func TestDeliverooCLI_ConfigAndHistory(t *testing.T) {
@@ -38,7 +49,7 @@ func TestDeliverooCLI_ConfigAndHistory(t *testing.T) {
 	if err != nil {
 		t.Fatalf("history: %v", err)
 	}
-	if !strings.Contains(out, "id=o1") {
+	if !strings.HasPrefix(out, "o1\tR\tdelivered") {
 		t.Fatalf("unexpected out: %s", out)
 	}
 
@@ -55,3 +66,460 @@ func TestDeliverooCLI_MissingBearerToken(t *testing.T) {
 		t.Fatalf("expected error")
 	}
 }
+
+func testJWT(exp time.Time) string {
+	payload := base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf(`{"exp":%d}`, exp.Unix())))
+	return "eyJhbGciOiJub25lIn0." + payload + ".sig"
+}
+
+func TestDeliverooCLI_LoginSessionLogout(t *testing.T) {
+	cfgPath := filepath.Join(t.TempDir(), "config.json")
+	setEnv(t, "DELIVEROO_BEARER_TOKEN", "")
+	setEnv(t, "DELIVEROO_COOKIE", "")
+	token := testJWT(time.Now().Add(2 * time.Hour))
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "Bearer "+token {
+			t.Errorf("authorization=%q", got)
+		}
+		if got := r.Header.Get("Cookie"); got != "roo_session=abc" {
+			t.Errorf("cookie=%q", got)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"orders":[{"id":"o1","status":"delivered","restaurant":{"name":"R"}}]}`))
+	}))
+	defer srv.Close()
+
+	if _, _, err := runCLI(cfgPath, []string{"deliveroo", "config", "set", "--market", "uk", "--base-url", srv.URL}, ""); err != nil {
+		t.Fatalf("config set: %v", err)
+	}
+	out, errOut, err := runCLI(cfgPath, []string{"deliveroo", "login", "--token-stdin", "--cookie", "roo_session=abc"}, "Bearer "+token+"\n")
+	if err != nil {
+		t.Fatalf("login: %v", err)
+	}
+	if !strings.Contains(out, "expires_at=") || !strings.Contains(errOut, "warning: deliveroo token expires in 2h") {
+		t.Fatalf("login out=%q err=%q", out, errOut)
+	}
+
+	out, _, err = runCLI(cfgPath, []string{"deliveroo", "session"}, "")
+	if err != nil || !strings.Contains(out, "bearer_token=***\ncookie=***\nexpires_at=") || strings.Contains(out, token) {
+		t.Fatalf("session: err=%v out=%s", err, out)
+	}
+
+	out, _, err = runCLI(cfgPath, []string{"deliveroo", "history", "--limit", "1"}, "")
+	if err != nil || !strings.HasPrefix(out, "o1\tR\t") {
+		t.Fatalf("history: err=%v out=%s", err, out)
+	}
+
+	if _, _, err := runCLI(cfgPath, []string{"deliveroo", "logout"}, ""); err != nil {
+		t.Fatalf("logout: %v", err)
+	}
+	if _, _, err := runCLI(cfgPath, []string{"deliveroo", "history"}, ""); err == nil || !strings.Contains(err.Error(), "missing bearer token") {
+		t.Fatalf("want missing token error, got %v", err)
+	}
+}
+
+func TestDeliverooCLI_OrdersFlagsAndTokenWarning(t *testing.T) {
+	cfgPath := filepath.Join(t.TempDir(), "config.json")
+	token := testJWT(time.Now().Add(2 * time.Hour))
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "Bearer "+token {
+			t.Errorf("authorization=%q", got)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"orders":[]}`))
+	}))
+	defer srv.Close()
+
+	if _, _, err := runCLI(cfgPath, []string{"deliveroo", "config", "set", "--base-url", srv.URL}, ""); err != nil {
+		t.Fatalf("config set: %v", err)
+	}
+	_, errOut, err := runCLI(cfgPath, []string{"deliveroo", "orders", "--once", "--market", "uk", "--bearer-token", token}, "")
+	if err != nil || !strings.Contains(errOut, "warning: deliveroo token expires in") {
+		t.Fatalf("orders: err=%v errOut=%q", err, errOut)
+	}
+
+	// The cross-provider commands open deliveroo through the registry and stay quiet.
+	setEnv(t, "DELIVEROO_BEARER_TOKEN", token)
+	_, errOut, err = runCLI(cfgPath, []string{"orders"}, "")
+	if err != nil || strings.Contains(errOut, "deliveroo token") {
+		t.Fatalf("timeline orders: err=%v errOut=%q", err, errOut)
+	}
+}
+
+func TestDeliverooTokenWarning(t *testing.T) {
+	now := time.Date(2025, 12, 20, 12, 0, 0, 0, time.UTC)
+	cases := []struct {
+		token string
+		want  string
+	}{
+		{"opaque", ""},
+		{testJWT(now.Add(48 * time.Hour)), ""},
+		{testJWT(now.Add(90 * time.Minute)), "expires in 1h30m"},
+		{testJWT(now.Add(-5 * time.Minute)), "expired 5m ago"},
+	}
+	for _, c := range cases {
+		got := deliverooTokenWarning(c.token, now)
+		if (c.want == "") != (got == "") || !strings.Contains(got, c.want) {
+			t.Fatalf("token exp %q: got %q, want %q", c.token, got, c.want)
+		}
+	}
+	if got := normalizeBearerToken("Authorization: Bearer abc "); got != "abc" {
+		t.Fatalf("normalize=%q", got)
+	}
+}
+
+func TestDeliverooSessionChrome(t *testing.T) {
+	cfgPath := filepath.Join(t.TempDir(), "config.json")
+	token := testJWT(time.Now().Add(72 * time.Hour))
+	older := testJWT(time.Now().Add(time.Hour))
+	header := "roo_guid=abc; tracking=" + older + "; session_token=" + url.QueryEscape("Bearer "+token)
+
+	orig := chromeLoadCookieHeader
+	defer func() { chromeLoadCookieHeader = orig }()
+	chromeLoadCookieHeader = func(ctx context.Context, opts chromecookies.Options) (chromecookies.Result, error) {
+		if opts.TargetURL != "https://deliveroo.co.uk/" || len(opts.FilterNames) != 0 {
+			t.Errorf("opts=%+v", opts)
+		}
+		return chromecookies.Result{CookieHeader: header, CookieCount: 3}, nil
+	}
+
+	if _, _, err := runCLI(cfgPath, []string{"deliveroo", "session", "chrome"}, ""); err == nil || !strings.Contains(err.Error(), "--url required") {
+		t.Fatalf("want --url error, got %v", err)
+	}
+	out, _, err := runCLI(cfgPath, []string{"deliveroo", "session", "chrome", "--url", "https://deliveroo.co.uk/"}, "")
+	if err != nil || !strings.Contains(out, "ok cookies=3") {
+		t.Fatalf("session chrome: err=%v out=%s", err, out)
+	}
+	cfg, err := config.Load(cfgPath)
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if d := cfg.Deliveroo(); d.BearerToken != token || d.Cookie != header {
+		t.Fatalf("deliveroo config=%+v", d)
+	}
+
+	if _, _, err := runCLI(cfgPath, []string{"deliveroo", "session", "chrome", "--url", "https://deliveroo.co.uk/", "--token-cookie", "roo_guid"}, ""); err != nil {
+		t.Fatalf("session chrome --token-cookie: %v", err)
+	}
+	if cfg, _ := config.Load(cfgPath); cfg.Deliveroo().BearerToken != "abc" {
+		t.Fatalf("token=%q", cfg.Deliveroo().BearerToken)
+	}
+
+	// --profile is the config profile; the Chrome one is --chrome-profile.
+	setEnv(t, profileEnv, "")
+	if _, _, err := runCLI(cfgPath, []string{"--profile", "nosuch", "deliveroo", "session", "chrome", "--url", "https://deliveroo.co.uk/", "--chrome-profile", "Profile 1"}, ""); err == nil || !strings.Contains(err.Error(), `unknown profile "nosuch"`) {
+		t.Fatalf("want unknown profile error, got %v", err)
+	}
+
+	header = "roo_guid=abc"
+	if _, _, err := runCLI(cfgPath, []string{"deliveroo", "session", "chrome", "--url", "https://deliveroo.co.uk/"}, ""); err == nil || !strings.Contains(err.Error(), "no bearer token (JWT) among the Chrome cookies (roo_guid)") {
+		t.Fatalf("want no token error, got %v", err)
+	}
+}
+
+func TestDeliverooCLI_Order(t *testing.T) {
+	cfgPath := filepath.Join(t.TempDir(), "config.json")
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/consumer/order-history/v1/orders/o1" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":"o1","order_number":"1234","status":"delivered","total":17.73,"currency_code":"gbp",` +
+			`"restaurant":{"name":"Burger Place"},"extra":true,` +
+			`"items":[{"name":"Burger","quantity":2,"total_price":13.5,"modifiers":[{"name":"Cheese","price":0.75}]}],` +
+			`"fees":[{"type":"delivery_fee","amount":2.49}],"rider_tip":1.74,` +
+			`"delivery_address":{"line1":"2 Low Rd","post_code":"E1 6AN","city":"London"}}`))
+	}))
+	defer srv.Close()
+
+	setEnv(t, "DELIVEROO_BEARER_TOKEN", "tok")
+	if _, _, err := runCLI(cfgPath, []string{"deliveroo", "config", "set", "--market", "uk", "--base-url", srv.URL}, ""); err != nil {
+		t.Fatalf("config set: %v", err)
+	}
+
+	out, _, err := runCLI(cfgPath, []string{"deliveroo", "order", "o1"}, "")
+	if err != nil {
+		t.Fatalf("order: %v", err)
+	}
+	for _, want := range []string{
+		"order=o1\n", "number=1234\n", "restaurant=Burger Place\n", "status=delivered\n", "total=17.73 GBP\n",
+		"- 2x Burger (13.50 GBP)\n", "  + Cheese (0.75 GBP)\n",
+		"- delivery fee (2.49 GBP)\n", "- rider tip (1.74 GBP)\n", "address=2 Low Rd, E1 6AN London\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("missing %q in:\n%s", want, out)
+		}
+	}
+
+	out, _, err = runCLI(cfgPath, []string{"deliveroo", "order", "o1", "--json"}, "")
+	if err != nil {
+		t.Fatalf("order --json: %v", err)
+	}
+	if !strings.Contains(out, `"extra": true`) {
+		t.Fatalf("expected raw JSON, got: %s", out)
+	}
+
+	if _, _, err := runCLI(cfgPath, []string{"deliveroo", "order", "o2"}, ""); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Fatalf("expected not found, got %v", err)
+	}
+}
+
+func TestDeliverooCLI_HistoryPaging(t *testing.T) {
+	cfgPath := filepath.Join(t.TempDir(), "config.json")
+
+	// Five orders, one per day, newest first.
+	var requests int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		q := r.URL.Query()
+		offset, limit := 0, 0
+		fmt.Sscan(q.Get("offset"), &offset)
+		fmt.Sscan(q.Get("limit"), &limit)
+		var orders []string
+		for i := offset; i < min(offset+limit, 5); i++ {
+			orders = append(orders, fmt.Sprintf(`{"id":"o%d","submitted_at":"2025-12-%02dT12:00:00Z"}`, i, 20-i))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"count":5,"orders":[%s]}`, strings.Join(orders, ","))
+	}))
+	defer srv.Close()
+
+	setEnv(t, "DELIVEROO_BEARER_TOKEN", "tok")
+	if _, _, err := runCLI(cfgPath, []string{"deliveroo", "config", "set", "--base-url", srv.URL}, ""); err != nil {
+		t.Fatalf("config set: %v", err)
+	}
+
+	ids := func(out string) string {
+		var got []string
+		for _, line := range strings.Split(strings.TrimSpace(out), "\n") {
+			got = append(got, strings.Fields(line)[0])
+		}
+		return strings.Join(got, ",")
+	}
+
+	out, _, err := runCLI(cfgPath, []string{"deliveroo", "history", "--page-size", "2", "--limit", "100"}, "")
+	if err != nil {
+		t.Fatalf("history: %v", err)
+	}
+	if got := ids(out); got != "o0,o1,o2,o3,o4" || requests != 3 {
+		t.Fatalf("ids=%s requests=%d", got, requests)
+	}
+
+	requests = 0
+	out, _, err = runCLI(cfgPath, []string{"deliveroo", "history", "--page-size", "2", "--limit", "3"}, "")
+	if err != nil {
+		t.Fatalf("history --limit: %v", err)
+	}
+	if got := ids(out); got != "o0,o1,o2" || requests != 2 {
+		t.Fatalf("ids=%s requests=%d", got, requests)
+	}
+
+	requests = 0
+	out, _, err = runCLI(cfgPath, []string{"deliveroo", "history", "--page-size", "1", "--since", "2025-12-17T00:00:00Z", "--until", "2025-12-19T00:00:00Z"}, "")
+	if err != nil {
+		t.Fatalf("history --since/--until: %v", err)
+	}
+	// o3 (Dec 17) is the last match; o4 (Dec 16) ends the walk.
+	if got := ids(out); got != "o2,o3" || requests != 5 {
+		t.Fatalf("ids=%s requests=%d", got, requests)
+	}
+
+	out, _, err = runCLI(cfgPath, []string{"deliveroo", "history", "--since", "2026-01-01"}, "")
+	if err != nil || strings.TrimSpace(out) != "no past orders" {
+		t.Fatalf("out=%q err=%v", out, err)
+	}
+
+	out, _, err = runCLI(cfgPath, []string{"deliveroo", "history", "--offset", "1", "--limit", "2", "--json"}, "")
+	if err != nil {
+		t.Fatalf("history --json: %v", err)
+	}
+	var orders []provider.Order
+	if err := json.Unmarshal([]byte(out), &orders); err != nil || len(orders) != 2 || orders[0].ID != "o1" || orders[0].Provider != provider.DeliverooName {
+		t.Fatalf("orders=%+v err=%v out=%s", orders, err, out)
+	}
+
+	if _, _, err := runCLI(cfgPath, []string{"deliveroo", "history", "--since", "yesterday"}, ""); err == nil {
+		t.Fatalf("expected --since error")
+	}
+}
+
+func TestDeliverooCLI_HistoryFiltersOnSubmission(t *testing.T) {
+	cfgPath := filepath.Join(t.TempDir(), "config.json")
+
+	// Placed late on Dec 19, delivered after midnight: Dec 19 by submission, Dec 20 by delivery.
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"count":1,"orders":[{"id":"late","status":"delivered","submitted_at":"2025-12-19T23:30:00Z","delivered_at":"2025-12-20T00:15:00Z"}]}`))
+	}))
+	defer srv.Close()
+
+	setEnv(t, "DELIVEROO_BEARER_TOKEN", "tok")
+	if _, _, err := runCLI(cfgPath, []string{"deliveroo", "config", "set", "--base-url", srv.URL}, ""); err != nil {
+		t.Fatalf("config set: %v", err)
+	}
+	for _, tc := range []struct {
+		args []string
+		want bool
+	}{
+		{[]string{"--until", "2025-12-20T00:00:00Z"}, true},
+		{[]string{"--since", "2025-12-20T00:00:00Z"}, false},
+	} {
+		out, _, err := runCLI(cfgPath, append([]string{"deliveroo", "history"}, tc.args...), "")
+		if err != nil || strings.HasPrefix(out, "late\t") != tc.want {
+			t.Fatalf("%v: err=%v out=%q", tc.args, err, out)
+		}
+	}
+}
+
+func TestDeliverooCLI_HistoryRetriesBlips(t *testing.T) {
+	cfgPath := filepath.Join(t.TempDir(), "config.json")
+
+	// The second page fails once (Retry-After: 0 keeps the test fast).
+	var requests int
+	failed := map[string]bool{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		offset := r.URL.Query().Get("offset")
+		if offset == "2" && !failed[offset] {
+			failed[offset] = true
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		o := 0
+		fmt.Sscan(offset, &o)
+		var orders []string
+		for i := o; i < min(o+2, 4); i++ {
+			orders = append(orders, fmt.Sprintf(`{"id":"o%d","submitted_at":"2025-12-%02dT12:00:00Z"}`, i, 20-i))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"count":4,"orders":[%s]}`, strings.Join(orders, ","))
+	}))
+	defer srv.Close()
+
+	setEnv(t, "DELIVEROO_BEARER_TOKEN", "tok")
+	if _, _, err := runCLI(cfgPath, []string{"deliveroo", "config", "set", "--base-url", srv.URL}, ""); err != nil {
+		t.Fatalf("config set: %v", err)
+	}
+
+	out, _, err := runCLI(cfgPath, []string{"deliveroo", "history", "--page-size", "2", "--limit", "100"}, "")
+	if err != nil {
+		t.Fatalf("history: %v", err)
+	}
+	if strings.Count(out, "\n") != 4 || requests != 3 {
+		t.Fatalf("out=%q requests=%d", out, requests)
+	}
+
+	clear(failed)
+	_, _, err = runCLI(cfgPath, []string{"deliveroo", "history", "--page-size", "2", "--limit", "100", "--http-retries", "0"}, "")
+	if deliveroo.KindOf(err) != deliveroo.KindServer {
+		t.Fatalf("expected a server error without retries, got %v", err)
+	}
+}
+
+func TestDeliverooCLI_OrdersWatch(t *testing.T) {
+	cfgPath := filepath.Join(t.TempDir(), "config.json")
+
+	eta := time.Now().Add(20 * time.Minute).UTC().Format(time.RFC3339)
+	polls := []string{
+		`{"orders":[{"id":"o1","status":"in_kitchen","estimated_delivery_at":"` + eta + `","restaurant":{"name":"R"}}]}`,
+		`{"orders":[{"id":"o1","status":"in_kitchen","estimated_delivery_at":"` + eta + `","restaurant":{"name":"R"}}]}`,
+		`{"orders":[{"id":"o1","status":"in_transit","estimated_delivery_at":"` + eta + `","restaurant":{"name":"R"}}]}`,
+		`{"orders":[{"id":"o1","status":"delivered","restaurant":{"name":"R"}}]}`,
+	}
+	var n int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("state") != "active" {
+			t.Fatalf("q=%v", r.URL.Query())
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(polls[min(n, len(polls)-1)]))
+		n++
+	}))
+	defer srv.Close()
+
+	setEnv(t, "DELIVEROO_BEARER_TOKEN", "tok")
+	if _, _, err := runCLI(cfgPath, []string{"deliveroo", "config", "set", "--base-url", srv.URL}, ""); err != nil {
+		t.Fatalf("config set: %v", err)
+	}
+
+	out, _, err := runCLI(cfgPath, []string{"deliveroo", "orders", "--interval", "5ms"}, "")
+	if err != nil {
+		t.Fatalf("orders: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	if len(lines) != 3 || n != 4 {
+		t.Fatalf("polls=%d out:\n%s", n, out)
+	}
+	if !strings.HasPrefix(lines[0], "o1\tR\tpreparing\teta ") || !strings.Contains(lines[0], "(in 2") {
+		t.Fatalf("unexpected list line: %q", lines[0])
+	}
+	if !strings.Contains(lines[1], "\tstatus\tdeliveroo\to1\tR\tpreparing -> rider on the way\teta ") {
+		t.Fatalf("unexpected status line: %q", lines[1])
+	}
+	if !strings.HasSuffix(lines[2], "\tdelivered\tdeliveroo\to1\tR\trider on the way -> delivered") {
+		t.Fatalf("unexpected delivered line: %q", lines[2])
+	}
+}
+
+func TestDeliverooCLI_Reorder(t *testing.T) {
+	cfgPath := filepath.Join(t.TempDir(), "config.json")
+
+	var calls []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, r.Method+" "+r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method + " " + r.URL.Path {
+		case "GET /consumer/order-history/v1/orders/o1/reorder":
+			_, _ = w.Write([]byte(`{"order_id":"o1","restaurant_id":"r1","restaurant":{"name":"R"},"restaurant_open":true,"currency_code":"gbp","items":[` +
+				`{"item_id":"i1","name":"Burger","quantity":2,"unit_price":6,"modifiers":[{"id":"m1","name":"Cheese","price":0.5}]},` +
+				`{"item_id":"i2","name":"Shake","available":false,"unavailable_reason":"sold out","unit_price":4}]}`))
+		case "PUT /consumer/basket/v1/basket":
+			_, _ = w.Write([]byte(`{"id":"b1","currency_code":"gbp","items":[{"name":"Burger","quantity":2,"total_price":13}],"total":13}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	setEnv(t, "DELIVEROO_BEARER_TOKEN", "tok")
+	if _, _, err := runCLI(cfgPath, []string{"deliveroo", "config", "set", "--base-url", srv.URL}, ""); err != nil {
+		t.Fatalf("config set: %v", err)
+	}
+	calls = nil
+
+	out, errOut, err := runCLI(cfgPath, []string{"deliveroo", "reorder", "o1"}, "")
+	if err != nil {
+		t.Fatalf("reorder: %v", err)
+	}
+	for _, want := range []string{
+		"restaurant=R\n", "restaurant_open=true\n", "- 2x Burger (Cheese) (13.00 GBP)\n",
+		"- 1x Shake [unavailable: sold out] (4.00 GBP)\n", "total_now=13.00 GBP\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("missing %q in:\n%s", want, out)
+		}
+	}
+	if !strings.Contains(errOut, "--confirm") || len(calls) != 1 {
+		t.Fatalf("preview must stay read-only: calls=%v stderr=%s", calls, errOut)
+	}
+
+	calls = nil
+	out, errOut, err = runCLI(cfgPath, []string{"deliveroo", "reorder", "o1", "--confirm"}, "")
+	if err != nil {
+		t.Fatalf("reorder --confirm: %v", err)
+	}
+	if got := strings.Join(calls, ","); got != "GET /consumer/order-history/v1/orders/o1/reorder,PUT /consumer/basket/v1/basket" {
+		t.Fatalf("calls=%s", got)
+	}
+	if !strings.Contains(out, "basket=b1\n") || !strings.Contains(out, "total=13.00 GBP\n") {
+		t.Fatalf("unexpected out:\n%s", out)
+	}
+	if !strings.Contains(errOut, "skipped unavailable item Shake") || !strings.Contains(errOut, "does not place an order") {
+		t.Fatalf("unexpected stderr:\n%s", errOut)
+	}
+}
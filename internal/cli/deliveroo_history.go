@@ -1,9 +1,10 @@
 package cli
 
 import (
-	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"math"
 	"os"
 	"strings"
 	"time"
@@ -11,132 +12,169 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/steipete/ordercli/internal/deliveroo"
+	"github.com/steipete/ordercli/internal/provider"
 )
 
 func newDeliverooHistoryCmd(st *state) *cobra.Command {
-	var market string
-	var baseURL string
-	var bearerToken string
-	var cookie string
+	var f deliverooClientFlags
+	var opts provider.DeliverooOptions
 	var offset int
-	var limit int
-	var includeUgc bool
-	var state string
+	var totalLimit int
+	var pageSize int
+	var since string
+	var until string
 	var asJSON bool
 
 	cmd := &cobra.Command{
 		Use:   "history",
-		Short: "List past orders (requires DELIVEROO_BEARER_TOKEN)",
+		Short: "List past orders (requires `deliveroo login` or DELIVEROO_BEARER_TOKEN)",
+		Args:  cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			cfg := st.deliveroo()
-
-			m := strings.TrimSpace(market)
-			if m == "" {
-				m = strings.TrimSpace(cfg.Market)
-			}
-			b := strings.TrimSpace(bearerToken)
-			if b == "" {
-				b = strings.TrimSpace(os.Getenv("DELIVEROO_BEARER_TOKEN"))
-			}
-			if b == "" {
-				return errors.New("missing bearer token (set DELIVEROO_BEARER_TOKEN or pass --bearer-token)")
-			}
-			c := strings.TrimSpace(cookie)
-			if c == "" {
-				c = strings.TrimSpace(os.Getenv("DELIVEROO_COOKIE"))
+			window, err := parseDateWindow(since, until, "--")
+			if err != nil {
+				return err
 			}
-
-			u := strings.TrimSpace(baseURL)
-			if u == "" {
-				u = strings.TrimSpace(cfg.BaseURL)
+			if totalLimit <= 0 {
+				totalLimit = 20
 			}
 
-			cl, err := deliveroo.NewClient(deliveroo.ClientOptions{
-				BaseURL:     u,
-				Market:      m,
-				BearerToken: b,
-				Cookie:      c,
-				Timeout:     20 * time.Second,
-			})
+			opts.State = strings.TrimSpace(opts.State)
+			p, err := newDeliverooProvider(st, f, opts)
 			if err != nil {
 				return err
 			}
 
-			resp, err := cl.OrderHistory(cmd.Context(), deliveroo.OrderHistoryParams{
-				Offset:     offset,
-				Limit:      limit,
-				IncludeUgc: includeUgc,
-				State:      strings.TrimSpace(state),
+			// The date filters skip orders, so the walk itself is unbounded and stops once
+			// totalLimit orders were printed.
+			out := cmd.OutOrStdout()
+			orders := []provider.Order{}
+			_, err = walkHistoryWindow(cmd.Context(), p, window, offset, math.MaxInt, min(max(pageSize, 1), 100), totalLimit, func(o provider.Order) bool {
+				orders = append(orders, o)
+				if !asJSON {
+					printOrderRow(out, o)
+				}
+				return true
 			})
 			if err != nil {
 				return err
 			}
 
 			if asJSON {
-				enc := json.NewEncoder(cmd.OutOrStdout())
-				enc.SetIndent("", "  ")
-				return enc.Encode(resp)
+				return writeJSON(out, orders)
 			}
-
-			out := cmd.OutOrStdout()
-			if len(resp.Orders) == 0 {
-				fmt.Fprintln(out, "no orders")
-				return nil
-			}
-			for _, o := range resp.Orders {
-				fmt.Fprintln(out, o.Summary())
+			if len(orders) == 0 {
+				fmt.Fprintln(out, "no past orders")
 			}
 			return nil
 		},
 	}
 
-	cmd.Flags().StringVar(&market, "market", "", "market (default: config market)")
-	cmd.Flags().StringVar(&baseURL, "base-url", "", "API base url (default: config base_url or derived from market)")
-	cmd.Flags().StringVar(&bearerToken, "bearer-token", "", "bearer token (or set DELIVEROO_BEARER_TOKEN)")
-	cmd.Flags().StringVar(&cookie, "cookie", "", "cookie header (or set DELIVEROO_COOKIE)")
-	cmd.Flags().IntVar(&offset, "offset", 0, "paging offset")
-	cmd.Flags().IntVar(&limit, "limit", 10, "paging limit")
-	cmd.Flags().BoolVar(&includeUgc, "include-ugc", false, "include UGC in response")
-	cmd.Flags().StringVar(&state, "state", "", "state filter (provider-specific)")
-	cmd.Flags().BoolVar(&asJSON, "json", false, "print raw JSON")
+	bindDeliverooClientFlags(cmd, &f)
+	cmd.Flags().IntVar(&offset, "offset", 0, "skip this many orders first")
+	cmd.Flags().IntVar(&totalLimit, "limit", 20, "max orders to print")
+	cmd.Flags().IntVar(&pageSize, "page-size", 20, "page size (API limit)")
+	cmd.Flags().StringVar(&since, "since", "", "only orders submitted on or after this date (YYYY-MM-DD or RFC 3339)")
+	cmd.Flags().StringVar(&until, "until", "", "only orders submitted before this date (YYYY-MM-DD or RFC 3339)")
+	cmd.Flags().BoolVar(&opts.IncludeUgc, "include-ugc", false, "include UGC in response")
+	cmd.Flags().StringVar(&opts.State, "state", "", "state filter (provider-specific; single request)")
+	cmd.Flags().BoolVar(&asJSON, "json", false, "print JSON (orders collected across pages)")
 	return cmd
 }
 
 func newDeliverooOrdersCmd(st *state) *cobra.Command {
+	var f deliverooClientFlags
 	var interval time.Duration
 	var once bool
+	var opts activeOrdersOptions
 
 	cmd := &cobra.Command{
 		Use:     "orders",
 		Aliases: []string{"active"},
-		Short:   "List active orders (best-effort via history state=active)",
+		Short:   "List active orders with ETA (--watch prints status changes)",
+		Long: "List active orders (order history with state=active) with their ETA countdown.\n\n" +
+			"--watch (or --interval) keeps polling and only prints status changes; it exits once every\n" +
+			"watched order was delivered, cancelled or is gone, and waits for one while none is active\n" +
+			"(--until-delivered=false to keep going, the default with --sse).",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			// Reuse history command plumbing by calling it in-process with --state active.
-			// Keep flags small for now; user can call `deliveroo history --state ...` for more control.
-			if interval <= 0 || once {
-				h := newDeliverooHistoryCmd(st)
-				h.SetArgs([]string{"--state", "active"})
-				h.SetOut(cmd.OutOrStdout())
-				h.SetErr(cmd.ErrOrStderr())
-				h.SetContext(cmd.Context())
-				return h.Execute()
-			}
-
-			for {
-				h := newDeliverooHistoryCmd(st)
-				h.SetArgs([]string{"--state", "active"})
-				h.SetOut(cmd.OutOrStdout())
-				h.SetErr(cmd.ErrOrStderr())
-				h.SetContext(cmd.Context())
-				if err := h.Execute(); err != nil {
-					return err
-				}
-				time.Sleep(interval)
+			p, err := newDeliverooProvider(st, f, provider.DeliverooOptions{})
+			if err != nil {
+				return err
 			}
+			opts.Watch = (opts.Watch || interval > 0) && !once
+			opts.Interval = interval
+			return runActiveOrders(cmd, st, p, opts)
 		},
 	}
 
-	cmd.Flags().DurationVar(&interval, "interval", 0, "poll interval (default: once)")
+	cmd.Flags().BoolVar(&opts.Watch, "watch", false, "poll active orders (default interval: 30s)")
+	cmd.Flags().DurationVar(&interval, "interval", 0, "poll interval (implies --watch)")
 	cmd.Flags().BoolVar(&once, "once", false, "fetch once (default)")
+	bindDeliverooClientFlags(cmd, &f)
+	bindWatchFlags(cmd, &opts)
 	return cmd
 }
+
+type deliverooClientFlags struct {
+	market      string
+	baseURL     string
+	bearerToken string
+	cookie      string
+	// stderr receives token expiry warnings; nil (registry, serve, mcp) stays quiet.
+	stderr func() io.Writer
+}
+
+func bindDeliverooClientFlags(cmd *cobra.Command, f *deliverooClientFlags) {
+	f.stderr = cmd.ErrOrStderr
+	cmd.Flags().StringVar(&f.market, "market", "", "market (default: config market)")
+	cmd.Flags().StringVar(&f.baseURL, "base-url", "", "API base url (default: config base_url or derived from market)")
+	cmd.Flags().StringVar(&f.bearerToken, "bearer-token", "", "bearer token (default: DELIVEROO_BEARER_TOKEN, then the stored session)")
+	cmd.Flags().StringVar(&f.cookie, "cookie", "", "cookie header (default: DELIVEROO_COOKIE, then the stored session)")
+}
+
+func newDeliverooClient(st *state, f deliverooClientFlags) (*deliveroo.Client, error) {
+	cfg := st.deliveroo()
+
+	m := strings.TrimSpace(f.market)
+	if m == "" {
+		m = strings.TrimSpace(cfg.Market)
+	}
+	// Flags win over the environment, which wins over the stored session.
+	b := strings.TrimSpace(f.bearerToken)
+	if b == "" {
+		b = strings.TrimSpace(os.Getenv("DELIVEROO_BEARER_TOKEN"))
+	}
+	c := strings.TrimSpace(f.cookie)
+	if c == "" {
+		c = strings.TrimSpace(os.Getenv("DELIVEROO_COOKIE"))
+	}
+	if b == "" {
+		b = cfg.BearerToken
+		if c == "" {
+			c = cfg.Cookie
+		}
+	}
+	if b == "" && st.replaying {
+		b = "replay" // the cassette has the responses; the header is redacted there anyway
+	}
+	if b == "" {
+		return nil, errors.New("missing bearer token (run `ordercli deliveroo login`, set DELIVEROO_BEARER_TOKEN or pass --bearer-token)")
+	}
+	if f.stderr != nil {
+		if w := deliverooTokenWarning(b, time.Now()); w != "" {
+			fmt.Fprintln(f.stderr(), w)
+		}
+	}
+
+	u := strings.TrimSpace(f.baseURL)
+	if u == "" {
+		u = strings.TrimSpace(cfg.BaseURL)
+	}
+
+	return deliveroo.NewClient(deliveroo.ClientOptions{
+		BaseURL:     u,
+		Market:      m,
+		BearerToken: b,
+		Cookie:      c,
+		HTTPClient:  st.httpClient(),
+	})
+}
@@ -0,0 +1,136 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/steipete/ordercli/internal/deliveroo"
+)
+
+func newDeliverooOrderCmd(st *state) *cobra.Command {
+	var f deliverooClientFlags
+	var asJSON bool
+
+	cmd := &cobra.Command{
+		Use:   "order <id>",
+		Short: "Show details for an order (items, modifiers, fees, tip, address)",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cl, err := newDeliverooClient(st, f)
+			if err != nil {
+				return err
+			}
+			o, err := cl.Order(cmd.Context(), args[0])
+			if errors.Is(err, deliveroo.ErrNotFound) {
+				return fmt.Errorf("order %s not found (ids are listed by `ordercli deliveroo history`)", args[0])
+			}
+			if err != nil {
+				return err
+			}
+
+			out := cmd.OutOrStdout()
+			if asJSON {
+				b, _ := json.MarshalIndent(o, "", "  ")
+				b = append(b, '\n')
+				_, _ = out.Write(b)
+				return nil
+			}
+			printDeliverooOrder(out, o)
+			return nil
+		},
+	}
+
+	bindDeliverooClientFlags(cmd, &f)
+	cmd.Flags().BoolVar(&asJSON, "json", false, "print raw JSON")
+	return cmd
+}
+
+// printDeliverooOrder mirrors printHistoryDetail (foodora history show).
+func printDeliverooOrder(out io.Writer, d deliveroo.OrderDetail) {
+	money := deliverooMoney(d.CurrencyCode)
+
+	fmt.Fprintf(out, "order=%s\n", d.ID)
+	if d.OrderNumber != "" && d.OrderNumber != d.ID {
+		fmt.Fprintf(out, "number=%s\n", d.OrderNumber)
+	}
+	restaurant := d.RestaurantName()
+	if restaurant != "" {
+		fmt.Fprintf(out, "restaurant=%s\n", restaurant)
+	}
+	if d.Restaurant != nil && d.Restaurant.Address != nil {
+		if addr := d.Restaurant.Address.String(); addr != "" {
+			fmt.Fprintf(out, "restaurant_address=%s\n", addr)
+		}
+	}
+	for _, ts := range []string{d.DeliveredAt, d.SubmittedAt} {
+		if t, err := time.Parse(time.RFC3339, strings.TrimSpace(ts)); err == nil {
+			fmt.Fprintf(out, "time=%s\n", t.In(time.Local).Format(time.RFC3339))
+			break
+		}
+	}
+	if d.Status != "" {
+		fmt.Fprintf(out, "status=%s\n", d.Status)
+	}
+	if d.Total != nil {
+		fmt.Fprintf(out, "total=%s\n", money(*d.Total))
+	}
+
+	if len(d.Items) > 0 {
+		fmt.Fprintln(out, "items:")
+		for _, it := range d.Items {
+			name := strings.TrimSpace(it.Name)
+			if name == "" {
+				continue
+			}
+			line := it.LineTotal()
+			switch {
+			case it.Quantity > 0 && line != 0:
+				fmt.Fprintf(out, "- %dx %s (%s)\n", it.Quantity, name, money(line))
+			case it.Quantity > 0:
+				fmt.Fprintf(out, "- %dx %s\n", it.Quantity, name)
+			default:
+				fmt.Fprintf(out, "- %s\n", name)
+			}
+			for _, m := range it.Modifiers {
+				mn := strings.TrimSpace(m.Name)
+				switch {
+				case mn == "":
+				case m.Price != 0:
+					fmt.Fprintf(out, "  + %s (%s)\n", mn, money(m.Price))
+				default:
+					fmt.Fprintf(out, "  + %s\n", mn)
+				}
+			}
+		}
+	}
+
+	if fees := d.Charges(); len(fees) > 0 {
+		fmt.Fprintln(out, "fees:")
+		for _, f := range fees {
+			fmt.Fprintf(out, "- %s (%s)\n", f.Name, money(f.Amount))
+		}
+	}
+	if addr := d.Address(); addr != "" {
+		fmt.Fprintf(out, "address=%s\n", addr)
+	}
+
+	// Helpful: show keys when we can't parse much.
+	if restaurant == "" && len(d.Items) == 0 {
+		b, _ := json.Marshal(d)
+		var item map[string]any
+		_ = json.Unmarshal(b, &item)
+		keys := make([]string, 0, len(item))
+		for k := range item {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		fmt.Fprintf(out, "keys=%s\n", strings.Join(keys, ","))
+	}
+}
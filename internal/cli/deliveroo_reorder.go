@@ -0,0 +1,169 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/steipete/ordercli/internal/deliveroo"
+)
+
+func newDeliverooReorderCmd(st *state) *cobra.Command {
+	var f deliverooClientFlags
+	var confirm bool
+	var asJSON bool
+
+	cmd := &cobra.Command{
+		Use:   "reorder <id>",
+		Short: "Reorder a past order (rebuilds the basket when --confirm; never places an order)",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id := strings.TrimSpace(args[0])
+			if id == "" {
+				return errors.New("missing order id")
+			}
+			cl, err := newDeliverooClient(st, f)
+			if err != nil {
+				return err
+			}
+
+			// Safe default: preview only (read-only availability check, basket untouched).
+			preview, err := cl.ReorderPreview(cmd.Context(), id)
+			if errors.Is(err, deliveroo.ErrNotFound) {
+				return fmt.Errorf("order %s not found (ids are listed by `ordercli deliveroo history`)", id)
+			}
+			if err != nil {
+				return err
+			}
+			out := cmd.OutOrStdout()
+
+			if !confirm {
+				if asJSON {
+					return writeJSON(out, preview)
+				}
+				printDeliverooReorderPreview(out, preview)
+				fmt.Fprintln(cmd.ErrOrStderr(), "hint: run with --confirm to replace the basket with these items (does not place an order)")
+				return nil
+			}
+
+			req, skipped := preview.BasketRequest()
+			if len(req.Items) == 0 {
+				return errors.New("none of the items are available; basket unchanged")
+			}
+			basket, err := cl.ReplaceBasket(cmd.Context(), req)
+			if err != nil {
+				return err
+			}
+
+			if asJSON {
+				return writeJSON(out, basket)
+			}
+			printDeliverooBasket(out, basket)
+			for _, it := range skipped {
+				fmt.Fprintf(cmd.ErrOrStderr(), "warning: skipped unavailable item %s\n", strings.TrimSpace(it.Name))
+			}
+			fmt.Fprintln(cmd.ErrOrStderr(), "note: this only builds a basket; it does not place an order")
+			return nil
+		},
+	}
+
+	bindDeliverooClientFlags(cmd, &f)
+	cmd.Flags().BoolVar(&confirm, "confirm", false, "replace the basket with the available items (never checks out)")
+	cmd.Flags().BoolVar(&asJSON, "json", false, "print JSON (preview, or the basket with --confirm)")
+	return cmd
+}
+
+func printDeliverooReorderPreview(out io.Writer, p deliveroo.ReorderPreview) {
+	money := deliverooMoney(p.CurrencyCode)
+
+	fmt.Fprintf(out, "order=%s\n", p.OrderID)
+	if p.Restaurant != nil && strings.TrimSpace(p.Restaurant.Name) != "" {
+		fmt.Fprintf(out, "restaurant=%s\n", strings.TrimSpace(p.Restaurant.Name))
+	}
+	if p.RestaurantOpen != nil {
+		fmt.Fprintf(out, "restaurant_open=%t\n", *p.RestaurantOpen)
+	}
+	if len(p.Items) == 0 {
+		fmt.Fprintln(out, "items=<none>")
+		return
+	}
+
+	var total float64
+	fmt.Fprintln(out, "items:")
+	for _, it := range p.Items {
+		name := strings.TrimSpace(it.Name)
+		if name == "" {
+			name = "<unknown>"
+		}
+		var mods []string
+		for _, m := range it.Modifiers {
+			if mn := strings.TrimSpace(m.Name); mn != "" {
+				mods = append(mods, mn)
+			}
+		}
+		if len(mods) > 0 {
+			name += " (" + strings.Join(mods, ", ") + ")"
+		}
+		if !it.IsAvailable() {
+			if r := strings.TrimSpace(it.UnavailableReason); r != "" {
+				name += " [unavailable: " + r + "]"
+			} else {
+				name += " [unavailable]"
+			}
+		} else {
+			total += it.LineTotal()
+		}
+
+		qty := max(it.Quantity, 1)
+		if line := it.LineTotal(); line != 0 {
+			fmt.Fprintf(out, "- %dx %s (%s)\n", qty, name, money(line))
+		} else {
+			fmt.Fprintf(out, "- %dx %s\n", qty, name)
+		}
+	}
+	if total != 0 {
+		fmt.Fprintf(out, "total_now=%s\n", money(total))
+	}
+}
+
+func printDeliverooBasket(out io.Writer, b deliveroo.Basket) {
+	money := deliverooMoney(b.CurrencyCode)
+
+	if b.ID != "" {
+		fmt.Fprintf(out, "basket=%s\n", b.ID)
+	}
+	if len(b.Items) == 0 {
+		fmt.Fprintln(out, "items=<none>")
+	} else {
+		fmt.Fprintln(out, "items:")
+		for _, it := range b.Items {
+			qty := max(it.Quantity, 1)
+			if line := it.LineTotal(); line != 0 {
+				fmt.Fprintf(out, "- %dx %s (%s)\n", qty, strings.TrimSpace(it.Name), money(line))
+			} else {
+				fmt.Fprintf(out, "- %dx %s\n", qty, strings.TrimSpace(it.Name))
+			}
+		}
+	}
+	if b.Subtotal != nil {
+		fmt.Fprintf(out, "subtotal=%s\n", money(*b.Subtotal))
+	}
+	if b.Total != nil {
+		fmt.Fprintf(out, "total=%s\n", money(*b.Total))
+	}
+}
+
+func deliverooMoney(currency string) func(float64) string {
+	currency = strings.ToUpper(strings.TrimSpace(currency))
+	return func(v float64) string {
+		s := strconv.FormatFloat(v, 'f', 2, 64)
+		if currency != "" {
+			s += " " + currency
+		}
+		return s
+	}
+}
@@ -0,0 +1,267 @@
+package cli
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steipete/ordercli/internal/chromecookies"
+	"github.com/steipete/ordercli/internal/config"
+	"golang.org/x/term"
+)
+
+// deliverooExpiryWarning is how long before the token expires commands start warning.
+const deliverooExpiryWarning = 24 * time.Hour
+
+func newDeliverooLoginCmd(st *state) *cobra.Command {
+	var tokenStdin bool
+	var bearerToken string
+	var cookie string
+
+	cmd := &cobra.Command{
+		Use:   "login",
+		Short: "Store a Deliveroo bearer token (and optional cookie) from a browser session",
+		Long: "Store a Deliveroo bearer token (Authorization header of a logged-in web session) and an\n" +
+			"optional Cookie header in the config (or the secrets backend, see `ordercli secrets`).\n" +
+			"Deliveroo tokens can't be refreshed; log in again once the token expires.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			token := bearerToken
+			if tokenStdin {
+				b, err := bufio.NewReader(os.Stdin).ReadBytes('\n')
+				if err != nil && !errors.Is(err, io.EOF) {
+					return err
+				}
+				token = string(b)
+			}
+			if strings.TrimSpace(token) == "" && term.IsTerminal(int(os.Stdin.Fd())) {
+				fmt.Fprint(cmd.ErrOrStderr(), "Bearer token: ")
+				b, err := term.ReadPassword(int(os.Stdin.Fd()))
+				fmt.Fprintln(cmd.ErrOrStderr())
+				if err != nil {
+					return err
+				}
+				token = string(b)
+			}
+			token = normalizeBearerToken(token)
+			if token == "" {
+				return errors.New("empty bearer token (pass --token-stdin or --bearer-token)")
+			}
+
+			cfg := st.deliveroo()
+			cfg.BearerToken = token
+			cfg.Cookie = strings.TrimSpace(cookie)
+			st.markDirty()
+
+			out := cmd.OutOrStdout()
+			fmt.Fprintln(out, "ok")
+			printDeliverooExpiry(out, *cfg, time.Now())
+			if w := deliverooTokenWarning(token, time.Now()); w != "" {
+				fmt.Fprintln(cmd.ErrOrStderr(), w)
+			}
+			return nil
+		},
+	}
+	cmd.Flags().BoolVar(&tokenStdin, "token-stdin", false, "read the bearer token from stdin (first line)")
+	cmd.Flags().StringVar(&bearerToken, "bearer-token", "", "bearer token (discouraged; prefer --token-stdin or prompt)")
+	cmd.Flags().StringVar(&cookie, "cookie", "", "Cookie header to send along (optional)")
+	return cmd
+}
+
+func newDeliverooSessionCmd(st *state) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "session",
+		Short: "Show the stored Deliveroo session and token expiry",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			out := cmd.OutOrStdout()
+			cfg := st.deliveroo()
+			if os.Getenv("DELIVEROO_BEARER_TOKEN") != "" {
+				fmt.Fprintln(out, "source=env (DELIVEROO_BEARER_TOKEN overrides the stored token)")
+			}
+			if !cfg.HasSession() {
+				fmt.Fprintln(out, "no stored session (run `ordercli deliveroo login`)")
+				return
+			}
+			fmt.Fprintln(out, "bearer_token=***")
+			if cfg.Cookie != "" {
+				fmt.Fprintln(out, "cookie=***")
+			}
+			printDeliverooExpiry(out, *cfg, time.Now())
+			if w := deliverooTokenWarning(cfg.BearerToken, time.Now()); w != "" {
+				fmt.Fprintln(cmd.ErrOrStderr(), w)
+			}
+		},
+	}
+	cmd.AddCommand(newDeliverooSessionChromeCmd(st))
+	return cmd
+}
+
+func newDeliverooSessionChromeCmd(st *state) *cobra.Command {
+	var siteURL string
+	var chromeProfile string
+	var cookiePath string
+	var tokenCookie string
+	var timeout time.Duration
+
+	cmd := &cobra.Command{
+		Use:   "chrome",
+		Short: "Import the Deliveroo session (bearer token + cookies) from Chrome",
+		Long: "Read the cookies of a logged-in Deliveroo site from the local Chrome profile. The bearer\n" +
+			"token is the cookie holding a JWT (the one expiring last, or --token-cookie); all cookies\n" +
+			"for the site are stored as the Cookie header.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if strings.TrimSpace(siteURL) == "" {
+				return errors.New("--url required (e.g. https://deliveroo.co.uk/)")
+			}
+			res, err := chromeLoadCookieHeader(cmd.Context(), chromecookies.Options{
+				TargetURL:          strings.TrimSpace(siteURL),
+				ChromeProfile:      chromeProfile,
+				ExplicitCookiePath: cookiePath,
+				Timeout:            timeout,
+				CacheDir:           filepath.Join(filepath.Dir(st.configPath), "chrome-cookies"),
+				LogWriter:          cmd.ErrOrStderr(),
+			})
+			if err != nil {
+				return err
+			}
+			if strings.TrimSpace(res.CookieHeader) == "" {
+				return fmt.Errorf("no cookies for %s in Chrome (are you logged in there?)", siteURL)
+			}
+
+			token, err := deliverooTokenFromCookies(res.CookieHeader, strings.TrimSpace(tokenCookie))
+			if err != nil {
+				return err
+			}
+			cfg := st.deliveroo()
+			cfg.BearerToken = token
+			cfg.Cookie = strings.TrimSpace(res.CookieHeader)
+			st.markDirty()
+
+			out := cmd.OutOrStdout()
+			fmt.Fprintf(out, "ok cookies=%d\n", res.CookieCount)
+			printDeliverooExpiry(out, *cfg, time.Now())
+			if w := deliverooTokenWarning(token, time.Now()); w != "" {
+				fmt.Fprintln(cmd.ErrOrStderr(), w)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&siteURL, "url", "", "Deliveroo site you're logged in on (e.g. https://deliveroo.co.uk/)")
+	cmd.Flags().StringVar(&chromeProfile, "chrome-profile", "", "Chrome profile name (Default, Profile 1, ...) or path to profile dir")
+	cmd.Flags().StringVar(&cookiePath, "cookie-path", "", "explicit Cookies DB path or profile dir (overrides --chrome-profile)")
+	cmd.Flags().StringVar(&tokenCookie, "token-cookie", "", "cookie that holds the bearer token (default: detect the JWT)")
+	cmd.Flags().DurationVar(&timeout, "timeout", 30*time.Second, "cookie read timeout (keychain prompts may need longer)")
+	return cmd
+}
+
+// deliverooTokenFromCookies picks the bearer token: the named cookie, else the cookie whose
+// value is a JWT with the latest expiry.
+func deliverooTokenFromCookies(header, name string) (string, error) {
+	kv := parseCookieHeader(header)
+	if name != "" {
+		v := normalizeBearerToken(unescapeCookie(kv[name]))
+		if v == "" {
+			return "", fmt.Errorf("cookie %q not found or empty", name)
+		}
+		return v, nil
+	}
+
+	var best string
+	var bestExp time.Time
+	names := make([]string, 0, len(kv))
+	for k, v := range kv {
+		names = append(names, k)
+		v = normalizeBearerToken(unescapeCookie(v))
+		if exp, ok := config.AccessTokenExpiresAt(v); ok && (best == "" || exp.After(bestExp)) {
+			best, bestExp = v, exp
+		}
+	}
+	if best == "" {
+		sort.Strings(names)
+		return "", fmt.Errorf("no bearer token (JWT) among the Chrome cookies (%s); pass --token-cookie or use `ordercli deliveroo login`", strings.Join(names, ","))
+	}
+	return best, nil
+}
+
+func unescapeCookie(v string) string {
+	v = strings.Trim(v, `"`)
+	if u, err := url.QueryUnescape(v); err == nil {
+		return u
+	}
+	return v
+}
+
+func newDeliverooLogoutCmd(st *state) *cobra.Command {
+	return &cobra.Command{
+		Use:   "logout",
+		Short: "Forget the stored Deliveroo token and cookie",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			cfg := st.deliveroo()
+			cfg.BearerToken = ""
+			cfg.Cookie = ""
+			st.markDirty()
+			fmt.Fprintln(cmd.OutOrStdout(), "ok")
+		},
+	}
+}
+
+// normalizeBearerToken accepts the token as copied from devtools, with or without the
+// "Authorization:" and "Bearer" prefixes.
+func normalizeBearerToken(s string) string {
+	s = strings.TrimSpace(s)
+	if rest, ok := cutPrefixFold(s, "authorization:"); ok {
+		s = strings.TrimSpace(rest)
+	}
+	if rest, ok := cutPrefixFold(s, "bearer "); ok {
+		s = strings.TrimSpace(rest)
+	}
+	return s
+}
+
+func cutPrefixFold(s, prefix string) (string, bool) {
+	if len(s) >= len(prefix) && strings.EqualFold(s[:len(prefix)], prefix) {
+		return s[len(prefix):], true
+	}
+	return s, false
+}
+
+func printDeliverooExpiry(out io.Writer, cfg config.DeliverooConfig, now time.Time) {
+	exp, ok := cfg.AccessTokenExpiresAt()
+	if !ok {
+		fmt.Fprintln(out, "expires_at=unknown")
+		return
+	}
+	fmt.Fprintf(out, "expires_at=%s (%s)\n", exp.Local().Format(time.RFC3339), untilText(exp, now))
+}
+
+// deliverooTokenWarning returns a warning when the token's JWT expiry has passed or is close.
+func deliverooTokenWarning(token string, now time.Time) string {
+	exp, ok := config.AccessTokenExpiresAt(token)
+	if !ok || exp.Sub(now) > deliverooExpiryWarning {
+		return ""
+	}
+	if !exp.After(now) {
+		return fmt.Sprintf("warning: deliveroo token expired %s; run `ordercli deliveroo login` with a fresh one", untilText(exp, now))
+	}
+	return fmt.Sprintf("warning: deliveroo token expires %s; run `ordercli deliveroo login` with a fresh one soon", untilText(exp, now))
+}
+
+func untilText(t, now time.Time) string {
+	d := t.Sub(now).Round(time.Minute)
+	if d < 0 {
+		return strings.TrimSuffix((-d).String(), "0s") + " ago"
+	}
+	return "in " + strings.TrimSuffix(d.String(), "0s")
+}
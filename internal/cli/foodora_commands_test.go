@@ -69,7 +69,7 @@ func TestFoodoraCLI_Flow_Login_History_Reorder(t *testing.T) {
 		if err != nil {
 			t.Fatalf("order: %v", err)
 		}
-		if !strings.Contains(out, "status=200") {
+		if !strings.Contains(out, "order=OC-1") || !strings.Contains(out, "status=Cooking") {
 			t.Fatalf("unexpected out=%s", out)
 		}
 	}
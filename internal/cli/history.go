@@ -2,10 +2,11 @@ package cli
 
 import (
 	"fmt"
+	"io"
 	"time"
 
 	"github.com/spf13/cobra"
-	"github.com/steipete/ordercli/internal/foodora"
+	"github.com/steipete/ordercli/internal/provider"
 )
 
 func newHistoryCmd(st *state) *cobra.Command {
@@ -13,73 +14,40 @@ func newHistoryCmd(st *state) *cobra.Command {
 	var pageSize int
 	var include string
 	var pandagoEnabled bool
+	var offline bool
 
 	cmd := &cobra.Command{
 		Use:   "history",
 		Short: "List past orders",
 		Args:  cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			c, err := newAuthedClient(st)
+			if offline {
+				return printArchivedHistory(cmd.OutOrStdout(), st, totalLimit)
+			}
+
+			p, err := newFoodoraProvider(st, provider.FoodoraOptions{
+				Include:        include,
+				PandaGoEnabled: pandagoEnabled,
+			})
 			if err != nil {
 				return err
 			}
 
-			limit := totalLimit
-			if limit <= 0 {
-				limit = 20
-			}
 			ps := pageSize
-			if ps <= 0 {
-				ps = 20
-			}
 			if ps > 100 {
 				ps = 100
 			}
 
 			out := cmd.OutOrStdout()
-			ctx := cmd.Context()
-
-			offset := 0
-			printed := 0
-			for printed < limit {
-				reqLimit := min(ps, limit-printed)
-				resp, err := c.OrderHistory(ctx, foodora.OrderHistoryRequest{
-					Include:        include,
-					Offset:         offset,
-					Limit:          reqLimit,
-					PandaGoEnabled: pandagoEnabled,
-				})
-				if err != nil {
-					return err
-				}
-
-				if len(resp.Data.Items) == 0 {
-					if printed == 0 {
-						fmt.Fprintln(out, "no past orders")
-					}
-					return nil
-				}
-
-				for _, o := range resp.Data.Items {
-					if printed >= limit {
-						return nil
-					}
-					fmt.Fprintf(out, "%s\t%s\t%s\t%s\n",
-						o.OrderCode,
-						historyVendor(o.Vendor),
-						historyStatus(o.CurrentStatus),
-						historyTime(o.ConfirmedDeliveryTime),
-					)
-					printed++
-				}
-
-				offset += len(resp.Data.Items)
-				if resp.Data.TotalCount > 0 && offset >= int(resp.Data.TotalCount) {
-					return nil
-				}
-				if len(resp.Data.Items) < reqLimit {
-					return nil
-				}
+			printed, err := provider.WalkHistory(cmd.Context(), p, totalLimit, ps, func(o provider.Order) error {
+				printOrderRow(out, o)
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+			if printed == 0 {
+				fmt.Fprintln(out, "no past orders")
 			}
 			return nil
 		},
@@ -89,34 +57,40 @@ func newHistoryCmd(st *state) *cobra.Command {
 	cmd.Flags().IntVar(&pageSize, "page-size", 20, "page size (API limit)")
 	cmd.Flags().StringVar(&include, "include", "order_products,order_details", "include fields")
 	cmd.Flags().BoolVar(&pandagoEnabled, "pandago-enabled", false, "set pandago_enabled=true")
+	cmd.Flags().BoolVar(&offline, "offline", false, "read from the local archive (see ordercli sync)")
 
 	cmd.AddCommand(newHistoryShowCmd(st))
 	return cmd
 }
 
-func historyVendor(v *foodora.OrderHistoryVendor) string {
-	if v == nil {
-		return ""
+func printArchivedHistory(out io.Writer, st *state, limit int) error {
+	a, err := st.loadArchive(provider.FoodoraName)
+	if err != nil {
+		return err
 	}
-	return v.Name
-}
-
-func historyStatus(s *foodora.OrderHistoryStatus) string {
-	if s == nil {
-		return ""
+	if limit <= 0 {
+		limit = 20
 	}
-	if s.Message != "" {
-		return s.Message
+	if len(a.Orders) == 0 {
+		fmt.Fprintln(out, "no past orders")
+		return nil
 	}
-	if s.Code != "" {
-		return string(s.Code)
+	for i, e := range a.Orders {
+		if i >= limit {
+			break
+		}
+		printOrderRow(out, archivedOrder(st, e))
 	}
-	return string(s.InternalStatusCode)
+	return nil
+}
+
+func printOrderRow(out io.Writer, o provider.Order) {
+	fmt.Fprintf(out, "%s\t%s\t%s\t%s\n", o.ID, o.Vendor.Name, o.Status, orderTime(o.Time))
 }
 
-func historyTime(t *foodora.OrderHistoryTime) string {
-	if t == nil || t.Date.IsZero() {
+func orderTime(t time.Time) string {
+	if t.IsZero() {
 		return ""
 	}
-	return t.Date.In(time.Local).Format(time.RFC3339)
+	return t.In(time.Local).Format(time.RFC3339)
 }
@@ -0,0 +1,216 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steipete/ordercli/internal/export"
+	"github.com/steipete/ordercli/internal/provider"
+)
+
+func newHistoryExportCmd(st *state) *cobra.Command {
+	var format string
+	var items string
+	var limit int
+	var pageSize int
+	var only []string
+	var offline bool
+	var output string
+	var appendOut bool
+	var expenseAccount string
+	var paymentAccount string
+	var currency string
+
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export order history (csv, jsonl, json, ledger, beancount)",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			f, err := export.ParseFormat(format)
+			if err != nil {
+				return err
+			}
+			im, err := export.ParseItems(items)
+			if err != nil {
+				return err
+			}
+
+			var orders []provider.OrderDetail
+			if offline {
+				orders, err = archivedDetails(st, limit)
+			} else {
+				orders, err = collectHistoryDetails(cmd, st, only, limit, pageSize, im != export.ItemsNone)
+			}
+			if err != nil {
+				return err
+			}
+
+			opts := export.Options{
+				Items:          im,
+				ExpenseAccount: expenseAccount,
+				PaymentAccount: paymentAccount,
+				Currency:       currency,
+			}
+			if !appendOut {
+				return writeOutput(cmd.OutOrStdout(), output, func(w io.Writer) error {
+					return export.Write(w, f, orders, opts)
+				})
+			}
+
+			// Append mode: keep the existing journal and skip orders it already books.
+			if f != export.FormatLedger && f != export.FormatBeancount {
+				return errors.New("--append requires --format ledger or beancount")
+			}
+			if strings.TrimSpace(output) == "" || output == "-" {
+				return errors.New("--append requires --output")
+			}
+			existing, err := os.ReadFile(output)
+			if err != nil && !errors.Is(err, os.ErrNotExist) {
+				return err
+			}
+			opts.Skip, err = export.ExistingOrderCodes(bytes.NewReader(existing))
+			if err != nil {
+				return err
+			}
+			return writeOutput(cmd.OutOrStdout(), output, func(w io.Writer) error {
+				if _, err := w.Write(existing); err != nil {
+					return err
+				}
+				if len(existing) > 0 && !bytes.HasSuffix(existing, []byte("\n\n")) {
+					if _, err := io.WriteString(w, "\n"); err != nil {
+						return err
+					}
+				}
+				return export.Write(w, f, orders, opts)
+			})
+		},
+	}
+
+	cmd.Flags().StringVar(&format, "format", "csv", "output format: csv, jsonl (ndjson), json, ledger (hledger), beancount")
+	cmd.Flags().StringVar(&items, "items", "none", "line items: none, nested (JSON), rows (one row per item)")
+	cmd.Flags().IntVar(&limit, "limit", 100, "max orders (merged)")
+	cmd.Flags().IntVar(&pageSize, "page-size", 50, "page size per provider request")
+	cmd.Flags().StringSliceVar(&only, "provider", nil, "only query these providers (repeatable; default: all logged-in)")
+	cmd.Flags().BoolVar(&offline, "offline", false, "export from the local archive (see ordercli sync)")
+	cmd.Flags().StringVarP(&output, "output", "o", "", "write to file instead of stdout")
+	cmd.Flags().BoolVar(&appendOut, "append", false, "append to --output, skipping order codes already in it (ledger/beancount)")
+	cmd.Flags().StringVar(&expenseAccount, "expense-account", export.DefaultExpenseAccount, "expense account (ledger/beancount)")
+	cmd.Flags().StringVar(&paymentAccount, "payment-account", export.DefaultPaymentAccount, "payment account (ledger/beancount)")
+	cmd.Flags().StringVar(&currency, "currency", "", "currency for orders without one (required for beancount if unknown)")
+	return cmd
+}
+
+// collectHistoryDetails merges history across providers; with details it fetches each order's
+// line items, falling back to the summary when a provider can't (a warning is printed once).
+func collectHistoryDetails(cmd *cobra.Command, st *state, only []string, limit, pageSize int, details bool) ([]provider.OrderDetail, error) {
+	ps, err := openProviders(st, only, cmd.ErrOrStderr())
+	if err != nil {
+		return nil, err
+	}
+	orders, err := historyFromProviders(cmd, ps, limit, pageSize)
+	if err != nil {
+		return nil, err
+	}
+	if len(orders) > limit {
+		orders = orders[:limit]
+	}
+
+	byName := map[string]provider.Provider{}
+	for _, p := range ps {
+		byName[p.Name()] = p
+	}
+	warned := map[string]bool{}
+
+	out := make([]provider.OrderDetail, 0, len(orders))
+	for _, o := range orders {
+		d := provider.OrderDetail{Order: o}
+		if p := byName[o.Provider]; details && p != nil && !warned[o.Provider] {
+			full, err := p.Order(cmd.Context(), o.ID)
+			switch {
+			case err == nil:
+				full.Order = mergeOrder(o, full.Order)
+				d = full
+			case errors.Is(err, provider.ErrUnsupported):
+				warned[o.Provider] = true
+				fmt.Fprintf(cmd.ErrOrStderr(), "warning: %s: order details not supported; exporting summaries\n", o.Provider)
+			default:
+				fmt.Fprintf(cmd.ErrOrStderr(), "warning: %s %s: %v\n", o.Provider, o.ID, err)
+			}
+		}
+		out = append(out, d)
+	}
+	return out, nil
+}
+
+// mergeOrder fills gaps in a detail response with fields known from the history list.
+func mergeOrder(summary, detail provider.Order) provider.Order {
+	if detail.ID == "" {
+		detail.ID = summary.ID
+	}
+	if detail.Provider == "" {
+		detail.Provider = summary.Provider
+	}
+	if detail.Vendor.Name == "" {
+		detail.Vendor = summary.Vendor
+	}
+	if detail.Status == "" {
+		detail.Status = summary.Status
+	}
+	if detail.Time.IsZero() {
+		detail.Time = summary.Time
+	}
+	if detail.Total.Amount == 0 {
+		detail.Total = summary.Total
+	}
+	if detail.Total.Currency == "" {
+		detail.Total.Currency = summary.Total.Currency
+	}
+	if detail.Items == 0 {
+		detail.Items = summary.Items
+	}
+	detail.Cancelled = detail.Cancelled || summary.Cancelled
+	return detail
+}
+
+func archivedDetails(st *state, limit int) ([]provider.OrderDetail, error) {
+	a, err := st.loadArchive(provider.FoodoraName)
+	if err != nil {
+		return nil, err
+	}
+	var out []provider.OrderDetail
+	for i, e := range a.Orders {
+		if limit > 0 && i >= limit {
+			break
+		}
+		out = append(out, archivedDetail(st, e))
+	}
+	return out, nil
+}
+
+// writeOutput writes to path (atomically) or to out when path is empty or "-".
+func writeOutput(out io.Writer, path string, fn func(io.Writer) error) error {
+	path = strings.TrimSpace(path)
+	if path == "" || path == "-" {
+		return fn(out)
+	}
+	tmp := path + ".tmp"
+	f, err := os.OpenFile(tmp, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+	if err != nil {
+		return err
+	}
+	if err := fn(f); err != nil {
+		_ = f.Close()
+		_ = os.Remove(tmp)
+		return err
+	}
+	if err := f.Close(); err != nil {
+		_ = os.Remove(tmp)
+		return err
+	}
+	return os.Rename(tmp, path)
+}
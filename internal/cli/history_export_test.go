@@ -0,0 +1,122 @@
+package cli
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// newHistoryExportTestServer serves one foodora order whose history entry names its currency
+// and item count, while the detail names neither.
+func newHistoryExportTestServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/orders/order_history" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("order_code") != "" {
+			_, _ = w.Write([]byte(`{"status":200,"data":{"total_count":1,"items":[{"order_code":"HIST-1","current_status":{"message":"delivered"},"confirmed_delivery_time":{"date":"2025-12-20T00:00:00Z","timezone":"Europe/Vienna"},"vendor":{"code":"V","name":"Test Vendor"},"total_value":12.3,"order_products":[{"name":"Burger","quantity":1,"total_price":12.3}]}]}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"status":200,"data":{"total_count":1,"items":[{"order_code":"HIST-1","current_status":{"message":"delivered"},"confirmed_delivery_time":{"date":"2025-12-20T00:00:00Z","timezone":"Europe/Vienna"},"vendor":{"code":"V","name":"Test Vendor"},"total_value":12.3,"currency":"EUR","order_products":[{"name":"Burger","quantity":1}]}]}}`))
+	}))
+}
+
+func TestHistoryExport_CSVWithItemRows(t *testing.T) {
+	cfgPath := filepath.Join(t.TempDir(), "config.json")
+	srv := newHistoryExportTestServer(t)
+	defer srv.Close()
+	writeLoggedInFoodoraConfig(t, cfgPath, srv.URL+"/")
+	setEnv(t, "DELIVEROO_BEARER_TOKEN", "")
+
+	out, errOut, err := runCLI(cfgPath, []string{"history", "export", "--items", "rows"}, "")
+	if err != nil {
+		t.Fatalf("export: %v err=%s", err, errOut)
+	}
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	if len(lines) != 2 || !strings.HasPrefix(lines[0], "provider,order_code,vendor,") {
+		t.Fatalf("unexpected out=%q", out)
+	}
+	if lines[1] != "foodora,HIST-1,Test Vendor,delivered,2025-12-20T00:00:00Z,12.30,EUR,1,Burger,1,12.30," {
+		t.Fatalf("unexpected row=%q", lines[1])
+	}
+}
+
+func TestHistoryExport_CSVDefaults(t *testing.T) {
+	cfgPath := filepath.Join(t.TempDir(), "config.json")
+	srv := newHistoryExportTestServer(t)
+	defer srv.Close()
+	writeLoggedInFoodoraConfig(t, cfgPath, srv.URL+"/")
+	setEnv(t, "DELIVEROO_BEARER_TOKEN", "")
+
+	// Without --items no details are fetched: currency and item count come from the history list.
+	out, errOut, err := runCLI(cfgPath, []string{"history", "export"}, "")
+	if err != nil {
+		t.Fatalf("export: %v err=%s", err, errOut)
+	}
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	if len(lines) != 2 || lines[1] != "foodora,HIST-1,Test Vendor,delivered,2025-12-20T00:00:00Z,12.30,EUR,1" {
+		t.Fatalf("unexpected out=%q", out)
+	}
+}
+
+func TestHistoryExport_JSONLToFile(t *testing.T) {
+	dir := t.TempDir()
+	cfgPath := filepath.Join(dir, "config.json")
+	srv := newFoodoraTestServer(t)
+	defer srv.Close()
+	writeLoggedInFoodoraConfig(t, cfgPath, srv.URL+"/")
+	setEnv(t, "DELIVEROO_BEARER_TOKEN", "")
+
+	outPath := filepath.Join(dir, "orders.jsonl")
+	if _, _, err := runCLI(cfgPath, []string{"history", "export", "--format", "jsonl", "-o", outPath}, ""); err != nil {
+		t.Fatalf("export: %v", err)
+	}
+	b, err := os.ReadFile(outPath)
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	if !strings.Contains(string(b), `"order_code":"HIST-1"`) || strings.Contains(string(b), `"items"`) {
+		t.Fatalf("unexpected file=%s", b)
+	}
+}
+
+func TestHistoryExport_BadFormat(t *testing.T) {
+	cfgPath := filepath.Join(t.TempDir(), "config.json")
+	if _, _, err := runCLI(cfgPath, []string{"history", "export", "--format", "xml"}, ""); err == nil {
+		t.Fatalf("expected error")
+	}
+}
+
+func TestHistoryExport_LedgerAppendIsDuplicateSafe(t *testing.T) {
+	dir := t.TempDir()
+	cfgPath := filepath.Join(dir, "config.json")
+	srv := newFoodoraTestServer(t)
+	defer srv.Close()
+	writeLoggedInFoodoraConfig(t, cfgPath, srv.URL+"/")
+	setEnv(t, "DELIVEROO_BEARER_TOKEN", "")
+
+	journal := filepath.Join(dir, "food.ledger")
+	if err := os.WriteFile(journal, []byte("; my books\n"), 0o600); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	args := []string{"history", "export", "--format", "ledger", "--currency", "EUR", "--append", "-o", journal}
+	for i := 0; i < 2; i++ {
+		if _, errOut, err := runCLI(cfgPath, args, ""); err != nil {
+			t.Fatalf("export %d: %v err=%s", i, err, errOut)
+		}
+	}
+	b, err := os.ReadFile(journal)
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	s := string(b)
+	if !strings.HasPrefix(s, "; my books\n") || strings.Count(s, "order_code: HIST-1") != 1 || !strings.Contains(s, "12.30 EUR") {
+		t.Fatalf("unexpected journal:\n%s", s)
+	}
+}
@@ -12,36 +12,54 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/steipete/ordercli/internal/foodora"
+	"github.com/steipete/ordercli/internal/provider"
 )
 
 func newHistoryShowCmd(st *state) *cobra.Command {
 	var include string
 	var itemReplacement bool
 	var asJSON bool
+	var offline bool
 
 	cmd := &cobra.Command{
 		Use:   "show <orderCode>",
 		Short: "Show details for a historical order (orders/order_history?order_code=...)",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			c, err := newAuthedClient(st)
-			if err != nil {
-				return err
+			var item foodora.OrderHistoryDetail
+			if offline {
+				a, err := st.loadArchive(provider.FoodoraName)
+				if err != nil {
+					return err
+				}
+				e, ok := a.Get(args[0])
+				if !ok {
+					return fmt.Errorf("order %s not in local archive (run `ordercli sync`)", args[0])
+				}
+				item, err = foodora.OrderHistoryDetailFromMap(e.Detail)
+				if err != nil {
+					return err
+				}
+			} else {
+				c, err := newAuthedClient(st)
+				if err != nil {
+					return err
+				}
+
+				resp, err := c.OrderHistoryByCode(cmd.Context(), foodora.OrderHistoryByCodeRequest{
+					OrderCode:       args[0],
+					Include:         include,
+					ItemReplacement: itemReplacement,
+				})
+				if err != nil {
+					return err
+				}
+				if len(resp.Data.Items) == 0 {
+					return errors.New("no order found")
+				}
+				item = resp.Data.Items[0]
 			}
 
-			resp, err := c.OrderHistoryByCode(cmd.Context(), foodora.OrderHistoryByCodeRequest{
-				OrderCode:       args[0],
-				Include:         include,
-				ItemReplacement: itemReplacement,
-			})
-			if err != nil {
-				return err
-			}
-			if len(resp.Data.Items) == 0 {
-				return errors.New("no order found")
-			}
-
-			item := resp.Data.Items[0]
 			out := cmd.OutOrStdout()
 
 			if asJSON {
@@ -59,74 +77,85 @@ func newHistoryShowCmd(st *state) *cobra.Command {
 	cmd.Flags().StringVar(&include, "include", "order_products,order_details", "include fields")
 	cmd.Flags().BoolVar(&itemReplacement, "item-replacement", false, "set item_replacement=true")
 	cmd.Flags().BoolVar(&asJSON, "json", false, "print raw JSON")
+	cmd.Flags().BoolVar(&offline, "offline", false, "read from the local archive (see ordercli sync)")
 	return cmd
 }
 
-func printHistoryDetail(out io.Writer, item map[string]any) {
-	code := asString(item["order_code"])
-	vendor := asString(nested(item, "vendor", "name"))
-	status := asString(nested(item, "current_status", "message"))
-	if status == "" {
-		status = asString(nested(item, "current_status", "code"))
+func printHistoryDetail(out io.Writer, d foodora.OrderHistoryDetail) {
+	vendor := ""
+	if d.Vendor != nil {
+		vendor = strings.TrimSpace(d.Vendor.Name)
 	}
-	when := formatOrderTime(nested(item, "confirmed_delivery_time", "date"))
-	total := formatMoney(item["total_value"])
-	if total == "" {
-		total = formatMoney(nested(item, "payment", "total_value"))
+	currency := d.CurrencyCode()
+	money := func(v float64) string {
+		s := strconv.FormatFloat(v, 'f', 2, 64)
+		if currency != "" {
+			s += " " + currency
+		}
+		return s
 	}
 
-	fmt.Fprintf(out, "order=%s\n", code)
+	fmt.Fprintf(out, "order=%s\n", d.OrderCode)
 	if vendor != "" {
 		fmt.Fprintf(out, "vendor=%s\n", vendor)
 	}
-	if when != "" {
-		fmt.Fprintf(out, "time=%s\n", when)
+	if t := d.ConfirmedDeliveryTime; t != nil && !t.Date.IsZero() {
+		fmt.Fprintf(out, "time=%s\n", t.Date.In(time.Local).Format(time.RFC3339))
 	}
-	if status != "" {
+	if status := d.Status(); status != "" {
 		fmt.Fprintf(out, "status=%s\n", status)
 	}
-	if total != "" {
-		fmt.Fprintf(out, "total=%s\n", total)
+	if total := d.Total(); total != 0 {
+		fmt.Fprintf(out, "total=%s\n", money(total))
 	}
 
-	products, _ := item["order_products"].([]any)
-	if len(products) > 0 {
+	if len(d.Products) > 0 {
 		fmt.Fprintln(out, "items:")
-		for _, raw := range products {
-			m, ok := raw.(map[string]any)
-			if !ok {
-				continue
-			}
-			name := asString(m["name"])
+		for _, p := range d.Products {
+			name := p.DisplayName()
 			if name == "" {
-				name = asString(m["title"])
-			}
-			qty := asInt(m["quantity"])
-			line := formatMoney(m["total_price"])
-			if line == "" {
-				line = formatMoney(m["total_value"])
-			}
-			if line == "" {
-				line = formatMoney(m["price"])
+				continue
 			}
+			qty := int(p.Quantity)
+			line := p.LineTotal()
 
 			switch {
-			case qty > 0 && line != "" && name != "":
-				fmt.Fprintf(out, "- %dx %s (%s)\n", qty, name, line)
-			case qty > 0 && name != "":
+			case qty > 0 && line != 0:
+				fmt.Fprintf(out, "- %dx %s (%s)\n", qty, name, money(line))
+			case qty > 0:
 				fmt.Fprintf(out, "- %dx %s\n", qty, name)
-			case name != "":
+			default:
 				fmt.Fprintf(out, "- %s\n", name)
 			}
+			for _, t := range p.Toppings {
+				tn := strings.TrimSpace(t.Name)
+				switch {
+				case tn == "":
+				case t.Price != 0:
+					fmt.Fprintf(out, "  + %s (%s)\n", tn, money(float64(t.Price)))
+				default:
+					fmt.Fprintf(out, "  + %s\n", tn)
+				}
+			}
 		}
 	}
 
-	if addr := asString(item["order_address"]); addr != "" {
+	if fees := d.Fees(); len(fees) > 0 {
+		fmt.Fprintln(out, "fees:")
+		for _, f := range fees {
+			fmt.Fprintf(out, "- %s (%s)\n", f.Name, money(f.Amount))
+		}
+	}
+	if pm := d.PaymentMethod(); pm != "" {
+		fmt.Fprintf(out, "payment=%s\n", pm)
+	}
+	if addr := d.Address(); addr != "" {
 		fmt.Fprintf(out, "address=%s\n", addr)
 	}
 
 	// Helpful: show keys when we can't parse much.
-	if vendor == "" && len(products) == 0 {
+	if vendor == "" && len(d.Products) == 0 {
+		item, _ := d.Map()
 		keys := make([]string, 0, len(item))
 		for k := range item {
 			keys = append(keys, k)
@@ -158,85 +187,3 @@ func asString(v any) string {
 		return strings.TrimSpace(fmt.Sprint(v))
 	}
 }
-
-func asInt(v any) int {
-	switch t := v.(type) {
-	case nil:
-		return 0
-	case int:
-		return t
-	case int64:
-		return int(t)
-	case float64:
-		return int(t)
-	case string:
-		i, _ := strconv.Atoi(strings.TrimSpace(t))
-		return i
-	default:
-		return 0
-	}
-}
-
-func nested(m map[string]any, keys ...string) any {
-	var cur any = m
-	for _, k := range keys {
-		mm, ok := cur.(map[string]any)
-		if !ok {
-			return nil
-		}
-		cur = mm[k]
-	}
-	return cur
-}
-
-func formatOrderTime(v any) string {
-	switch t := v.(type) {
-	case nil:
-		return ""
-	case string:
-		s := strings.TrimSpace(t)
-		if s == "" {
-			return ""
-		}
-		if tt, err := time.Parse(time.RFC3339Nano, s); err == nil {
-			return tt.In(time.Local).Format(time.RFC3339)
-		}
-		if tt, err := time.Parse(time.RFC3339, s); err == nil {
-			return tt.In(time.Local).Format(time.RFC3339)
-		}
-		return s
-	case float64:
-		iv := int64(t)
-		if iv > 1_000_000_000_000 {
-			return time.UnixMilli(iv).In(time.Local).Format(time.RFC3339)
-		}
-		return time.Unix(iv, 0).In(time.Local).Format(time.RFC3339)
-	default:
-		return asString(v)
-	}
-}
-
-func formatMoney(v any) string {
-	switch t := v.(type) {
-	case nil:
-		return ""
-	case float64:
-		if t == 0 {
-			return ""
-		}
-		return strconv.FormatFloat(t, 'f', 2, 64)
-	case int:
-		if t == 0 {
-			return ""
-		}
-		return strconv.FormatFloat(float64(t), 'f', 2, 64)
-	case string:
-		s := strings.TrimSpace(t)
-		if s == "" || s == "0" || s == "0.0" || s == "0.00" {
-			return ""
-		}
-		return s
-	default:
-		return ""
-	}
-}
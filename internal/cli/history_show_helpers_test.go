@@ -1,7 +1,12 @@
 package cli
 
 import (
+	"bytes"
+	"encoding/json"
+	"strings"
 	"testing"
+
+	"github.com/steipete/ordercli/internal/foodora"
 )
 
 func TestAsString(t *testing.T) {
@@ -19,50 +24,39 @@ func TestAsString(t *testing.T) {
 	}
 }
 
-func TestAsInt(t *testing.T) {
-	if asInt(float64(3.9)) != 3 {
-		t.Fatalf("unexpected")
-	}
-	if asInt(" 12 ") != 12 {
-		t.Fatalf("unexpected")
-	}
-}
-
-func TestNested(t *testing.T) {
-	m := map[string]any{"a": map[string]any{"b": map[string]any{"c": "x"}}}
-	if got := nested(m, "a", "b", "c"); got != "x" {
-		t.Fatalf("got %v", got)
-	}
-	if nested(m, "a", "nope") != nil {
-		t.Fatalf("expected nil")
+func TestPrintHistoryDetail(t *testing.T) {
+	var d foodora.OrderHistoryDetail
+	raw := `{"order_code":"X","vendor":{"name":"V"},"total_value":"14.80","currency":"eur",
+		"order_products":[{"name":"Pizza","variation_name":"large","quantity":2,"total_price":12,"toppings":[{"name":"Olives","price":0.5},{"name":"Basil"}]}],
+		"delivery_fee":2.3,"rider_tip":1,"vouchers":[{"code":"HELLO","value":1}],
+		"payment":{"method":"card"},"delivery_address":{"street":"Main","building":1,"postcode":"1010","city":"Vienna"}}`
+	if err := json.Unmarshal([]byte(raw), &d); err != nil {
+		t.Fatalf("decode: %v", err)
 	}
-}
 
-func TestFormatMoney(t *testing.T) {
-	if formatMoney(float64(0)) != "" {
-		t.Fatalf("expected empty")
+	var buf bytes.Buffer
+	printHistoryDetail(&buf, d)
+	out := buf.String()
+	for _, want := range []string{
+		"total=14.80 EUR\n",
+		"- 2x Pizza (large) (12.00 EUR)\n",
+		"  + Olives (0.50 EUR)\n",
+		"  + Basil\n",
+		"fees:\n- delivery fee (2.30 EUR)\n- rider tip (1.00 EUR)\n- voucher HELLO (-1.00 EUR)\n",
+		"payment=card\n",
+		"address=Main 1, 1010 Vienna\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("missing %q in:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "keys=") {
+		t.Fatalf("unexpected keys fallback:\n%s", out)
 	}
-	if formatMoney(float64(1.2)) != "1.20" {
-		t.Fatalf("unexpected")
-	}
-	if formatMoney("0.00") != "" {
-		t.Fatalf("expected empty")
-	}
-	if formatMoney("2.50") != "2.50" {
-		t.Fatalf("unexpected")
-	}
-}
 
-func TestFormatOrderTime(t *testing.T) {
-	if formatOrderTime(nil) != "" {
-		t.Fatalf("expected empty")
-	}
-	// unix seconds
-	if got := formatOrderTime(float64(1_700_000_000)); got == "" {
-		t.Fatalf("expected non-empty")
-	}
-	// unix millis
-	if got := formatOrderTime(float64(1_700_000_000_000)); got == "" {
-		t.Fatalf("expected non-empty")
+	buf.Reset()
+	printHistoryDetail(&buf, foodora.OrderHistoryDetail{OrderCode: "Y"})
+	if !strings.Contains(buf.String(), "keys=order_code\n") {
+		t.Fatalf("expected keys fallback:\n%s", buf.String())
 	}
 }
@@ -211,7 +211,7 @@ func oauthPassword(ctx context.Context, st *state, cmd *cobra.Command, browser b
 	if browser {
 		profileDir := strings.TrimSpace(cmd.Flag("browser-profile").Value.String())
 		if profileDir == "" {
-			profileDir = filepath.Join(filepath.Dir(st.configPath), "browser-profile")
+			profileDir = filepath.Join(st.dataDir(), "browser-profile")
 		}
 		tok, mfa, sess, err := browserOAuthTokenPassword(ctx, req, browserauth.PasswordOptions{
 			BaseURL:   cfg.BaseURL,
@@ -268,6 +268,7 @@ func oauthPassword(ctx context.Context, st *state, cmd *cobra.Command, browser b
 			}
 			return ""
 		}(),
+		HTTPClient: st.httpClient(),
 	})
 	if err != nil {
 		return foodora.AuthToken{}, nil, err
@@ -0,0 +1,248 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steipete/ordercli/internal/foodora"
+	"github.com/steipete/ordercli/internal/mcp"
+	"github.com/steipete/ordercli/internal/provider"
+	"github.com/steipete/ordercli/internal/version"
+)
+
+func newMCPCmd(st *state) *cobra.Command {
+	return &cobra.Command{
+		Use:   "mcp",
+		Short: "Run a Model Context Protocol server on stdin/stdout (foodora tools)",
+		Long: "Run a Model Context Protocol server on stdin/stdout.\n\n" +
+			"Tools: history, order_details, active_orders, reorder_preview. The reorder_confirm tool\n" +
+			"(adds items to the cart, never places an order) is only exposed after\n" +
+			"`ordercli foodora config set --mcp-allow-reorder`.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return newMCPServer(st).Serve(cmd.Context(), cmd.InOrStdin(), cmd.OutOrStdout())
+		},
+	}
+}
+
+func newMCPServer(st *state) *mcp.Server {
+	t := &mcpTools{foodoraSession: &foodoraSession{st: st}}
+	srv := mcp.NewServer("ordercli", version.Version)
+
+	srv.AddTool(mcp.Tool{
+		Name: "history",
+		Description: "List past foodora orders, newest first. Filter by vendor name (case-insensitive substring) " +
+			"and by order date (since/until as YYYY-MM-DD or RFC 3339; until is exclusive).",
+		InputSchema: objectSchema(map[string]any{
+			"vendor": stringProp("vendor name substring"),
+			"since":  stringProp("earliest order date (YYYY-MM-DD or RFC 3339)"),
+			"until":  stringProp("orders before this date (YYYY-MM-DD or RFC 3339)"),
+			"limit":  intProp("max orders to return (default 20, max 100)"),
+			"scan":   intProp("max orders to look through when filtering (default 200, max 1000)"),
+		}),
+		Handler: t.history,
+	})
+	srv.AddTool(mcp.Tool{
+		Name:        "order_details",
+		Description: "Show a past foodora order: items with options, fees, payment method and delivery address.",
+		InputSchema: objectSchema(map[string]any{"order_code": stringProp("order code from history")}, "order_code"),
+		Handler:     t.orderDetails,
+	})
+	srv.AddTool(mcp.Tool{
+		Name:        "active_orders",
+		Description: "List foodora orders that are currently in progress, with status and vendor.",
+		InputSchema: objectSchema(map[string]any{}),
+		Handler:     t.activeOrders,
+	})
+	srv.AddTool(mcp.Tool{
+		Name:        "reorder_preview",
+		Description: "Preview what reordering a past foodora order would add to the cart. Read-only.",
+		InputSchema: objectSchema(map[string]any{"order_code": stringProp("order code from history")}, "order_code"),
+		Handler:     t.reorderPreview,
+	})
+	if st.foodora().MCPAllowReorder {
+		srv.AddTool(mcp.Tool{
+			Name: "reorder_confirm",
+			Description: "Add the items of a past foodora order to the cart (does not place the order). " +
+				"Only call this after the user explicitly asked to reorder.",
+			InputSchema: objectSchema(map[string]any{
+				"order_code": stringProp("order code from history"),
+				"address_id": stringProp("customer address id (needed when there are several)"),
+			}, "order_code"),
+			Handler: t.reorderConfirm,
+		})
+	}
+	return srv
+}
+
+type mcpTools struct {
+	*foodoraSession
+}
+
+type orderCodeArgs struct {
+	OrderCode string `json:"order_code"`
+}
+
+func (a orderCodeArgs) code() (string, error) {
+	code := strings.TrimSpace(a.OrderCode)
+	if code == "" {
+		return "", errors.New("missing order_code")
+	}
+	return code, nil
+}
+
+func (t *mcpTools) history(ctx context.Context, raw json.RawMessage) (any, error) {
+	var args struct {
+		Vendor string `json:"vendor"`
+		Since  string `json:"since"`
+		Until  string `json:"until"`
+		Limit  int    `json:"limit"`
+		Scan   int    `json:"scan"`
+	}
+	if err := mcp.DecodeArgs(raw, &args); err != nil {
+		return nil, err
+	}
+	window, err := parseDateWindow(args.Since, args.Until, "")
+	if err != nil {
+		return nil, err
+	}
+	limit := args.Limit
+	if limit <= 0 {
+		limit = 20
+	}
+	limit = min(limit, 100)
+	scan := args.Scan
+	if scan <= 0 {
+		scan = 200
+	}
+	scan = min(max(scan, limit), 1000)
+	vendor := strings.ToLower(strings.TrimSpace(args.Vendor))
+
+	p, err := t.foodoraProvider()
+	if err != nil {
+		return nil, err
+	}
+
+	orders := []provider.Order{}
+	scanned, err := walkHistoryWindow(ctx, p, window, 0, scan, 50, limit, func(o provider.Order) bool {
+		if vendor != "" && !strings.Contains(strings.ToLower(o.Vendor.Name), vendor) {
+			return false
+		}
+		orders = append(orders, o)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return map[string]any{"orders": orders, "scanned": scanned}, nil
+}
+
+func (t *mcpTools) orderDetails(ctx context.Context, raw json.RawMessage) (any, error) {
+	var args orderCodeArgs
+	if err := mcp.DecodeArgs(raw, &args); err != nil {
+		return nil, err
+	}
+	code, err := args.code()
+	if err != nil {
+		return nil, err
+	}
+	p, err := t.foodoraProvider()
+	if err != nil {
+		return nil, err
+	}
+	return p.Order(ctx, code)
+}
+
+func (t *mcpTools) activeOrders(ctx context.Context, raw json.RawMessage) (any, error) {
+	if err := mcp.DecodeArgs(raw, &struct{}{}); err != nil {
+		return nil, err
+	}
+	p, err := t.foodoraProvider()
+	if err != nil {
+		return nil, err
+	}
+	page, err := p.ActiveOrders(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if page.Orders == nil {
+		page.Orders = []provider.Order{}
+	}
+	return map[string]any{"orders": page.Orders, "poll_in_sec": int(page.PollInterval / time.Second)}, nil
+}
+
+func (t *mcpTools) reorderPreview(ctx context.Context, raw json.RawMessage) (any, error) {
+	var args orderCodeArgs
+	if err := mcp.DecodeArgs(raw, &args); err != nil {
+		return nil, err
+	}
+	code, err := args.code()
+	if err != nil {
+		return nil, err
+	}
+	p, err := t.foodoraProvider()
+	if err != nil {
+		return nil, err
+	}
+	return p.ReorderPreview(ctx, code)
+}
+
+// reorderConfirm is only registered when the config opts in (see newMCPServer).
+func (t *mcpTools) reorderConfirm(ctx context.Context, raw json.RawMessage) (any, error) {
+	var args struct {
+		orderCodeArgs
+		AddressID string `json:"address_id"`
+	}
+	if err := mcp.DecodeArgs(raw, &args); err != nil {
+		return nil, err
+	}
+	code, err := args.code()
+	if err != nil {
+		return nil, err
+	}
+	c, err := t.foodoraClient()
+	if err != nil {
+		return nil, err
+	}
+
+	addrs, err := c.CustomerAddresses(ctx)
+	if err != nil {
+		return nil, err
+	}
+	addr, err := pickCustomerAddress(addrs.Data.Items, args.AddressID)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.OrderReorder(ctx, code, foodora.ReorderRequestBody{
+		Address:     addr,
+		ReorderTime: foodora.FormatReorderTime(time.Now()),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return map[string]any{
+		"order_code": code,
+		"cart":       resp.Data,
+		"note":       "items were added to the cart; the order was not placed",
+	}, nil
+}
+
+func objectSchema(props map[string]any, required ...string) map[string]any {
+	s := map[string]any{"type": "object", "properties": props, "additionalProperties": false}
+	if len(required) > 0 {
+		s["required"] = required
+	}
+	return s
+}
+
+func stringProp(desc string) map[string]any {
+	return map[string]any{"type": "string", "description": desc}
+}
+
+func intProp(desc string) map[string]any {
+	return map[string]any{"type": "integer", "minimum": 0, "description": desc}
+}
@@ -0,0 +1,123 @@
+package cli
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+type mcpReply struct {
+	ID     int `json:"id"`
+	Result struct {
+		Tools []struct {
+			Name string `json:"name"`
+		} `json:"tools"`
+		Content []struct {
+			Text string `json:"text"`
+		} `json:"content"`
+		StructuredContent map[string]any `json:"structuredContent"`
+		IsError           bool           `json:"isError"`
+	} `json:"result"`
+	Error *struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+func runMCP(t *testing.T, cfgPath string, lines ...string) map[int]mcpReply {
+	t.Helper()
+	out, _, err := runCLI(cfgPath, []string{"mcp"}, strings.Join(lines, "\n")+"\n")
+	if err != nil {
+		t.Fatalf("mcp: %v", err)
+	}
+	replies := map[int]mcpReply{}
+	for _, line := range strings.Split(strings.TrimSpace(out), "\n") {
+		var r mcpReply
+		if err := json.Unmarshal([]byte(line), &r); err != nil {
+			t.Fatalf("decode %q: %v", line, err)
+		}
+		replies[r.ID] = r
+	}
+	return replies
+}
+
+func mcpToolNames(r mcpReply) string {
+	var names []string
+	for _, tl := range r.Result.Tools {
+		names = append(names, tl.Name)
+	}
+	return strings.Join(names, ",")
+}
+
+func TestMCP_Tools(t *testing.T) {
+	cfgPath := filepath.Join(t.TempDir(), "config.json")
+	fd := newFoodoraTestServer(t)
+	defer fd.Close()
+	writeLoggedInFoodoraConfig(t, cfgPath, fd.URL+"/")
+
+	replies := runMCP(t, cfgPath,
+		`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2025-06-18","capabilities":{},"clientInfo":{"name":"test","version":"0"}}}`,
+		`{"jsonrpc":"2.0","method":"notifications/initialized"}`,
+		`{"jsonrpc":"2.0","id":2,"method":"tools/list"}`,
+		`{"jsonrpc":"2.0","id":3,"method":"tools/call","params":{"name":"history","arguments":{"vendor":"test vendor","since":"2025-12-01","until":"2026-01-01"}}}`,
+		`{"jsonrpc":"2.0","id":4,"method":"tools/call","params":{"name":"history","arguments":{"vendor":"other"}}}`,
+		`{"jsonrpc":"2.0","id":5,"method":"tools/call","params":{"name":"order_details","arguments":{"order_code":"HIST-1"}}}`,
+		`{"jsonrpc":"2.0","id":6,"method":"tools/call","params":{"name":"active_orders"}}`,
+		`{"jsonrpc":"2.0","id":7,"method":"tools/call","params":{"name":"reorder_confirm","arguments":{"order_code":"HIST-1"}}}`,
+		`{"jsonrpc":"2.0","id":8,"method":"tools/call","params":{"name":"order_details","arguments":{}}}`,
+	)
+
+	if got := mcpToolNames(replies[2]); got != "history,order_details,active_orders,reorder_preview" {
+		t.Fatalf("tools=%s", got)
+	}
+
+	hist := replies[3].Result
+	orders, _ := hist.StructuredContent["orders"].([]any)
+	if hist.IsError || len(orders) != 1 || !strings.Contains(hist.Content[0].Text, "HIST-1") {
+		t.Fatalf("history=%+v", hist)
+	}
+	if orders, _ := replies[4].Result.StructuredContent["orders"].([]any); len(orders) != 0 {
+		t.Fatalf("filtered history=%v", orders)
+	}
+
+	detail := replies[5].Result.StructuredContent
+	if detail["id"] != "HIST-1" || !strings.Contains(replies[5].Result.Content[0].Text, "Burger") {
+		t.Fatalf("order_details=%v", detail)
+	}
+
+	if orders, _ := replies[6].Result.StructuredContent["orders"].([]any); len(orders) != 1 {
+		t.Fatalf("active_orders=%+v", replies[6].Result)
+	}
+
+	if replies[7].Error == nil || !strings.Contains(replies[7].Error.Message, "unknown tool") {
+		t.Fatalf("reorder_confirm must not be available without opt-in: %+v", replies[7])
+	}
+
+	if !replies[8].Result.IsError || !strings.Contains(replies[8].Result.Content[0].Text, "missing order_code") {
+		t.Fatalf("want tool error, got %+v", replies[8].Result)
+	}
+}
+
+func TestMCP_ReorderConfirmOptIn(t *testing.T) {
+	cfgPath := filepath.Join(t.TempDir(), "config.json")
+	fd := newFoodoraTestServer(t)
+	defer fd.Close()
+	writeLoggedInFoodoraConfig(t, cfgPath, fd.URL+"/")
+
+	if _, _, err := runCLI(cfgPath, []string{"foodora", "config", "set", "--mcp-allow-reorder"}, ""); err != nil {
+		t.Fatalf("config set: %v", err)
+	}
+
+	replies := runMCP(t, cfgPath,
+		`{"jsonrpc":"2.0","id":1,"method":"tools/list"}`,
+		`{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":"reorder_confirm","arguments":{"order_code":"HIST-1"}}}`,
+	)
+	if got := mcpToolNames(replies[1]); !strings.HasSuffix(got, ",reorder_confirm") {
+		t.Fatalf("tools=%s", got)
+	}
+	res := replies[2].Result
+	if res.IsError || res.StructuredContent["order_code"] != "HIST-1" || res.StructuredContent["cart"] == nil {
+		t.Fatalf("reorder_confirm=%+v", res)
+	}
+}
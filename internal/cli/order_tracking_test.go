@@ -0,0 +1,65 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steipete/ordercli/internal/foodora"
+)
+
+func TestPrintOrderTracking(t *testing.T) {
+	raw := `{"code":"OC-9","status_messages":{"subtitle":"Your rider is on the way","titles":[
+		{"name":"Order received","is_filled":true,"timestamp":"2025-12-20T18:00:00Z"},
+		{"name":"On the way","active":true},
+		{"name":"Delivered"}]},
+		"delivery_window":{"from":"2025-12-20T18:40:00Z","to":"2025-12-20T18:50:00Z"},
+		"vendor":{"name":"Pizza Place","latitude":48.2,"longitude":16.37},
+		"rider":{"name":"Sam","vehicle_type":"bicycle","location":{"latitude":"48.21","longitude":"16.38"}},
+		"delivery_address":{"street":"Main","building":"1","city":"Vienna"}}`
+	var tr foodora.OrderTracking
+	if err := json.Unmarshal([]byte(raw), &tr); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+
+	var buf bytes.Buffer
+	printOrderTracking(&buf, "ignored", tr, time.Date(2025, 12, 20, 18, 15, 0, 0, time.UTC))
+	out := buf.String()
+	for _, want := range []string{
+		"order=OC-9\n",
+		"status=Your rider is on the way\n",
+		"(in 25m)\n",
+		"vendor=Pizza Place\n",
+		"vendor_location=48.200000,16.370000\n",
+		"rider=Sam (bicycle)\n",
+		"rider_location=48.210000,16.380000\n",
+		"address=Main 1, Vienna\n",
+		"progress:\n[x] Order received\t",
+		"[>] On the way\n[ ] Delivered\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("missing %q in:\n%s", want, out)
+		}
+	}
+}
+
+func TestFoodoraOrder_JSON(t *testing.T) {
+	cfgPath := t.TempDir() + "/config.json"
+	srv := newFoodoraTestServer(t)
+	defer srv.Close()
+	writeLoggedInFoodoraConfig(t, cfgPath, srv.URL+"/")
+
+	out, _, err := runCLI(cfgPath, []string{"foodora", "order", "OC-1", "--json"}, "")
+	if err != nil {
+		t.Fatalf("order --json: %v", err)
+	}
+	var v map[string]any
+	if err := json.Unmarshal([]byte(out), &v); err != nil {
+		t.Fatalf("decode: %v out=%s", err, out)
+	}
+	if _, ok := v["status_messages"]; !ok {
+		t.Fatalf("unexpected: %s", out)
+	}
+}
@@ -4,54 +4,54 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/steipete/ordercli/internal/config"
 	"github.com/steipete/ordercli/internal/foodora"
+	"github.com/steipete/ordercli/internal/provider"
 	"github.com/steipete/ordercli/internal/version"
+	"github.com/steipete/ordercli/internal/watch"
 )
 
 func newOrdersCmd(st *state) *cobra.Command {
-	var watch bool
+	var opts activeOrdersOptions
 
 	cmd := &cobra.Command{
 		Use:   "orders",
-		Short: "List active orders",
+		Short: "List active orders (--watch prints status changes)",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			c, err := newAuthedClient(st)
+			p, err := newFoodoraProvider(st, provider.FoodoraOptions{})
 			if err != nil {
 				return err
 			}
-
-			ctx := cmd.Context()
-			for {
-				resp, err := c.ActiveOrders(ctx)
-				if err != nil {
-					return err
-				}
-				printActiveOrders(cmd, resp.Data.ActiveOrders)
-
-				if !watch {
-					return nil
-				}
-				sleep := 30 * time.Second
-				if resp.Data.PollInSeconds != nil && *resp.Data.PollInSeconds > 0 {
-					sleep = time.Duration(*resp.Data.PollInSeconds) * time.Second
-				}
-				time.Sleep(sleep)
-			}
+			return runActiveOrders(cmd, st, p, opts)
 		},
 	}
-	cmd.Flags().BoolVar(&watch, "watch", false, "poll active orders")
+	cmd.Flags().BoolVar(&opts.Watch, "watch", false, "poll active orders")
+	bindWatchFlags(cmd, &opts)
 	return cmd
 }
 
+func sleepContext(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
 func newOrderCmd(st *state) *cobra.Command {
-	return &cobra.Command{
+	var asJSON bool
+
+	cmd := &cobra.Command{
 		Use:   "order <orderCode>",
-		Short: "Show details for a single order (tracking/orders/{orderCode})",
+		Short: "Track a single order (tracking/orders/{orderCode})",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			c, err := newAuthedClient(st)
@@ -62,13 +62,82 @@ func newOrderCmd(st *state) *cobra.Command {
 			if err != nil {
 				return err
 			}
-			fmt.Fprintf(cmd.OutOrStdout(), "status=%d\n", resp.Status)
-			if v, ok := resp.Data["status_messages"]; ok {
-				fmt.Fprintf(cmd.OutOrStdout(), "status_messages=%v\n", v)
+			if asJSON {
+				return writeJSON(cmd.OutOrStdout(), resp.Data)
 			}
+			printOrderTracking(cmd.OutOrStdout(), args[0], resp.Data, time.Now())
 			return nil
 		},
 	}
+	cmd.Flags().BoolVar(&asJSON, "json", false, "print raw tracking JSON")
+	return cmd
+}
+
+func printOrderTracking(out io.Writer, code string, t foodora.OrderTracking, now time.Time) {
+	if id := t.ID(); id != "" {
+		code = id
+	}
+	fmt.Fprintf(out, "order=%s\n", code)
+	if s := t.StatusText(); s != "" {
+		fmt.Fprintf(out, "status=%s\n", s)
+	}
+	if t.IsDelivered {
+		fmt.Fprintln(out, "delivered=true")
+	}
+	if from, to := t.ETAWindow(); !from.IsZero() {
+		eta := orderTime(from)
+		if !to.Equal(from) {
+			eta += ".." + orderTime(to)
+		}
+		if d := from.Sub(now).Round(time.Minute); d > 0 {
+			eta += fmt.Sprintf(" (in %s)", strings.TrimSuffix(d.String(), "0s"))
+		}
+		fmt.Fprintf(out, "eta=%s\n", eta)
+	}
+	if v := t.Vendor; v != nil && v.Name != "" {
+		fmt.Fprintf(out, "vendor=%s\n", v.Name)
+	}
+	if loc, ok := t.VendorLocation(); ok {
+		fmt.Fprintf(out, "vendor_location=%s\n", loc)
+	}
+	if r := t.Rider; r != nil && r.Name != "" {
+		rider := r.Name
+		if r.VehicleType != "" {
+			rider += " (" + r.VehicleType + ")"
+		}
+		fmt.Fprintf(out, "rider=%s\n", rider)
+	}
+	if loc, ok := t.RiderLocation(); ok {
+		fmt.Fprintf(out, "rider_location=%s\n", loc)
+	}
+	if a := t.DeliveryAddress; a != nil {
+		if s := a.String(); s != "" {
+			fmt.Fprintf(out, "address=%s\n", s)
+		}
+	}
+	if loc, ok := t.AddressLocation(); ok {
+		fmt.Fprintf(out, "address_location=%s\n", loc)
+	}
+
+	steps := t.Steps()
+	if len(steps) == 0 {
+		return
+	}
+	fmt.Fprintln(out, "progress:")
+	for _, s := range steps {
+		mark := "[ ]"
+		switch {
+		case s.Active:
+			mark = "[>]"
+		case s.Done:
+			mark = "[x]"
+		}
+		if s.Time.IsZero() {
+			fmt.Fprintf(out, "%s %s\n", mark, s.Name)
+			continue
+		}
+		fmt.Fprintf(out, "%s %s\t%s\n", mark, s.Name, orderTime(s.Time))
+	}
 }
 
 func newAuthedClient(st *state) (*foodora.Client, error) {
@@ -76,7 +145,7 @@ func newAuthedClient(st *state) (*foodora.Client, error) {
 	if cfg.BaseURL == "" {
 		return nil, errors.New("missing base_url (run `ordercli foodora config set --country ...`)")
 	}
-	if !cfg.HasSession() {
+	if !cfg.HasSession() && !st.replaying {
 		return nil, errors.New("not logged in (run `ordercli foodora login ...`)")
 	}
 
@@ -106,13 +175,15 @@ func newAuthedClient(st *state) (*foodora.Client, error) {
 			}
 			return ""
 		}(),
+		HTTPClient: st.httpClient(),
 	})
 	if err != nil {
 		return nil, err
 	}
 
 	now := time.Now()
-	if cfg.TokenLikelyExpired(now) {
+	// Without a session (--replay) there is nothing to refresh.
+	if cfg.HasSession() && cfg.TokenLikelyExpired(now) {
 		sec, err := st.resolveClientSecret(context.Background(), cfg.OAuthClientID)
 		if err != nil {
 			return nil, err
@@ -149,17 +220,19 @@ func newAuthedClient(st *state) (*foodora.Client, error) {
 	return c, nil
 }
 
-func printActiveOrders(cmd *cobra.Command, orders []foodora.ActiveOrder) {
+func printActiveOrders(cmd *cobra.Command, orders []provider.Order) {
 	out := cmd.OutOrStdout()
 	if len(orders) == 0 {
 		fmt.Fprintln(out, "no active orders")
 		return
 	}
+	now := time.Now()
 	for _, o := range orders {
-		status := o.Status.Subtitle
-		if status == "" && len(o.Status.Titles) > 0 {
-			status = o.Status.Titles[0].Name
+		if o.ETA.IsZero() {
+			fmt.Fprintf(out, "%s\t%s\t%s\n", o.ID, o.Vendor.Name, o.Status)
+			continue
 		}
-		fmt.Fprintf(out, "%s\t%s\t%s\n", o.Code, o.Vendor.Name, status)
+		fmt.Fprintf(out, "%s\t%s\t%s\teta %s (%s)\n", o.ID, o.Vendor.Name, o.Status,
+			o.ETA.In(time.Local).Format("15:04"), watch.Countdown(o.ETA, now))
 	}
 }
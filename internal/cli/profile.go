@@ -0,0 +1,152 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steipete/ordercli/internal/config"
+)
+
+func newProfileCmd(st *state) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "profile",
+		Short: "Manage named profiles (separate accounts/countries in one config)",
+	}
+	cmd.AddCommand(newProfileListCmd(st))
+	cmd.AddCommand(newProfileAddCmd(st))
+	cmd.AddCommand(newProfileUseCmd(st))
+	cmd.AddCommand(newProfileRemoveCmd(st))
+	return cmd
+}
+
+// isProfileCmd reports whether cmd is `profile` or one of its subcommands; they must work
+// while the selected profile doesn't exist (yet).
+func isProfileCmd(cmd *cobra.Command) bool {
+	for c := cmd; c != nil && c.HasParent(); c = c.Parent() {
+		if c.Name() == "profile" && !c.Parent().HasParent() {
+			return true
+		}
+	}
+	return false
+}
+
+func newProfileListCmd(st *state) *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List profiles (* marks the selected one)",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			for _, name := range st.cfg.ProfileNames() {
+				p, _ := st.cfg.Profile(name)
+				mark := " "
+				if name == st.profileName() {
+					mark = "*"
+				}
+				line := mark + " " + name
+				if f := p.Foodora; f != nil {
+					line += fmt.Sprintf("\tfoodora=%s\tlogged_in=%t", orDash(f.BaseURL), f.HasSession())
+				}
+				if d := p.Deliveroo; d != nil && d.Market != "" {
+					line += "\tdeliveroo=" + d.Market
+				}
+				fmt.Fprintln(cmd.OutOrStdout(), line)
+			}
+		},
+	}
+}
+
+func newProfileAddCmd(st *state) *cobra.Command {
+	var country string
+	var use bool
+
+	cmd := &cobra.Command{
+		Use:   "add <name>",
+		Short: "Create an empty profile",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := strings.TrimSpace(args[0])
+			var preset countryPreset
+			if country != "" {
+				var ok bool
+				preset, ok = findPreset(strings.ToUpper(country))
+				if !ok {
+					return fmt.Errorf("unknown country preset %q (see `ordercli foodora countries`)", country)
+				}
+			}
+			p, err := st.cfg.AddProfile(name)
+			if err != nil {
+				return err
+			}
+			if country != "" {
+				f := p.EnsureFoodora()
+				f.BaseURL = preset.BaseURL
+				f.GlobalEntityID = preset.GlobalEntityID
+				f.TargetCountryISO = preset.TargetISO
+			}
+			if use {
+				st.cfg.CurrentProfile = name
+			}
+			st.markDirty()
+			fmt.Fprintf(cmd.OutOrStdout(), "added profile %s (log in with `ordercli --profile %s foodora login`)\n", name, name)
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&country, "country", "", "foodora country preset (HU, SK, DL, AT)")
+	cmd.Flags().BoolVar(&use, "use", false, "make it the current profile")
+	return cmd
+}
+
+func newProfileUseCmd(st *state) *cobra.Command {
+	return &cobra.Command{
+		Use:   "use <name>",
+		Short: "Select the profile used when neither --profile nor ORDERCLI_PROFILE is set",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := strings.TrimSpace(args[0])
+			if _, ok := st.cfg.Profile(name); !ok {
+				return fmt.Errorf("unknown profile %q (see `ordercli profile list`)", name)
+			}
+			if name == config.DefaultProfile {
+				name = ""
+			}
+			st.cfg.CurrentProfile = name
+			st.markDirty()
+			return nil
+		},
+	}
+}
+
+func newProfileRemoveCmd(st *state) *cobra.Command {
+	return &cobra.Command{
+		Use:   "remove <name>",
+		Short: "Delete a profile and its tokens (local archive files are kept)",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := strings.TrimSpace(args[0])
+			var keys []string
+			for _, f := range st.cfg.SecretFields() {
+				if strings.HasPrefix(f.Key, name+"/") {
+					keys = append(keys, f.Key)
+				}
+			}
+			if err := st.cfg.RemoveProfile(name); err != nil {
+				return err
+			}
+			st.markDirty()
+			// Drop the references first: a failed save must not leave them pointing at
+			// deleted secrets.
+			if err := st.save(); err != nil {
+				return err
+			}
+			return st.deleteSecrets(keys)
+		},
+	}
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
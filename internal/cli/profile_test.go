@@ -0,0 +1,136 @@
+package cli
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/steipete/ordercli/internal/config"
+	"github.com/steipete/ordercli/internal/secrets"
+)
+
+func TestProfileCommands(t *testing.T) {
+	cfgPath := filepath.Join(t.TempDir(), "config.json")
+	setEnv(t, profileEnv, "")
+
+	if _, _, err := runCLI(cfgPath, []string{"foodora", "config", "set", "--country", "HU"}, ""); err != nil {
+		t.Fatalf("config set: %v", err)
+	}
+	if _, _, err := runCLI(cfgPath, []string{"profile", "add", "at", "--country", "AT"}, ""); err != nil {
+		t.Fatalf("profile add: %v", err)
+	}
+	if _, _, err := runCLI(cfgPath, []string{"profile", "add", "work"}, ""); err != nil {
+		t.Fatalf("profile add: %v", err)
+	}
+	if _, _, err := runCLI(cfgPath, []string{"--profile", "work", "foodora", "config", "set", "--base-url", "https://work.example/api/v5/"}, ""); err != nil {
+		t.Fatalf("config set work: %v", err)
+	}
+
+	out, _, err := runCLI(cfgPath, []string{"profile", "list"}, "")
+	if err != nil {
+		t.Fatalf("profile list: %v", err)
+	}
+	want := []string{
+		"* default\tfoodora=https://hu.fd-api.com/api/v5/",
+		"  at\tfoodora=https://mj.fd-api.com/api/v5/",
+		"  work\tfoodora=https://work.example/api/v5/",
+	}
+	for _, w := range want {
+		if !strings.Contains(out, w) {
+			t.Fatalf("missing %q in:\n%s", w, out)
+		}
+	}
+
+	// The env var, then the current profile, select a profile when --profile is absent.
+	setEnv(t, profileEnv, "work")
+	out, _, err = runCLI(cfgPath, []string{"foodora", "config", "show"}, "")
+	if err != nil || !strings.Contains(out, "profile=work\nbase_url=https://work.example/api/v5/") {
+		t.Fatalf("show (env): err=%v out=%s", err, out)
+	}
+	setEnv(t, profileEnv, "")
+	if _, _, err := runCLI(cfgPath, []string{"profile", "use", "at"}, ""); err != nil {
+		t.Fatalf("profile use: %v", err)
+	}
+	out, _, err = runCLI(cfgPath, []string{"foodora", "config", "show"}, "")
+	if err != nil || !strings.Contains(out, "base_url=https://mj.fd-api.com/api/v5/") {
+		t.Fatalf("show (current): err=%v out=%s", err, out)
+	}
+	out, _, err = runCLI(cfgPath, []string{"--profile", "default", "foodora", "config", "show"}, "")
+	if err != nil || !strings.Contains(out, "base_url=https://hu.fd-api.com/api/v5/") || strings.Contains(out, "profile=") {
+		t.Fatalf("show (default): err=%v out=%s", err, out)
+	}
+
+	if _, _, err := runCLI(cfgPath, []string{"--profile", "nope", "foodora", "config", "show"}, ""); err == nil || !strings.Contains(err.Error(), `unknown profile "nope"`) {
+		t.Fatalf("want unknown profile error, got %v", err)
+	}
+	// Profile commands still work when the selected profile doesn't exist.
+	if _, _, err := runCLI(cfgPath, []string{"--profile", "nope", "profile", "add", "nope"}, ""); err != nil {
+		t.Fatalf("profile add with missing selection: %v", err)
+	}
+
+	if _, _, err := runCLI(cfgPath, []string{"profile", "remove", "at"}, ""); err != nil {
+		t.Fatalf("profile remove: %v", err)
+	}
+	out, _, _ = runCLI(cfgPath, []string{"profile", "list"}, "")
+	if strings.Contains(out, "at\t") || !strings.HasPrefix(out, "* default") {
+		t.Fatalf("after remove:\n%s", out)
+	}
+}
+
+func TestProfileDataDir(t *testing.T) {
+	st := &state{configPath: filepath.Join("/cfg", "config.json")}
+	if got := st.dataDir(); got != "/cfg" {
+		t.Fatalf("default dataDir=%s", got)
+	}
+	st.profile = "work"
+	if got := st.dataDir(); got != filepath.Join("/cfg", "profiles", "work") {
+		t.Fatalf("work dataDir=%s", got)
+	}
+}
+
+func TestStateProviders_UnknownProfile(t *testing.T) {
+	for _, name := range []string{"nope", "../x"} {
+		st := &state{cfg: config.New(), profile: name}
+		if _, err := st.providers(); err == nil || !strings.Contains(err.Error(), "unknown profile") {
+			t.Fatalf("%s: unexpected err: %v", name, err)
+		}
+		if len(st.cfg.Profiles) != 0 {
+			t.Fatalf("%s: profile created: %v", name, st.cfg.Profiles)
+		}
+	}
+}
+
+func TestProfileRemove_DeletesSecrets(t *testing.T) {
+	keyring := useFakeKeyring(t)
+	cfgPath := filepath.Join(t.TempDir(), "config.json")
+	setEnv(t, profileEnv, "")
+
+	cfg := config.New()
+	cfg.Secrets = &config.SecretsConfig{Backend: secrets.BackendKeyring}
+	cfg.Foodora().AccessToken = config.SecretRef("default/foodora/access_token")
+	work, err := cfg.AddProfile("work")
+	if err != nil {
+		t.Fatalf("add: %v", err)
+	}
+	work.Foodora = &config.FoodoraConfig{AccessToken: config.SecretRef("work/foodora/access_token")}
+	work.Deliveroo = &config.DeliverooConfig{BearerToken: config.SecretRef("work/deliveroo/bearer_token")}
+	if err := config.Save(cfgPath, cfg); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+	// client_secret is a leftover the config no longer references.
+	if err := keyring.write(map[string]string{
+		"default/foodora/access_token": "a",
+		"work/foodora/access_token":    "b",
+		"work/foodora/client_secret":   "c",
+		"work/deliveroo/bearer_token":  "d",
+	}); err != nil {
+		t.Fatalf("keyring: %v", err)
+	}
+
+	if _, _, err := runCLI(cfgPath, []string{"profile", "remove", "work"}, ""); err != nil {
+		t.Fatalf("profile remove: %v", err)
+	}
+	if got := keyring.load(); len(got) != 1 || got["default/foodora/access_token"] != "a" {
+		t.Fatalf("keyring=%v", got)
+	}
+}
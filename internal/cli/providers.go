@@ -1,6 +1,12 @@
 package cli
 
-import "github.com/spf13/cobra"
+import (
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steipete/ordercli/internal/provider"
+)
 
 func newFoodoraCmd(st *state) *cobra.Command {
 	cmd := &cobra.Command{
@@ -26,7 +32,65 @@ func newDeliverooCmd(st *state) *cobra.Command {
 		Short: "Deliveroo",
 	}
 	cmd.AddCommand(newDeliverooConfigCmd(st))
+	cmd.AddCommand(newDeliverooLoginCmd(st))
+	cmd.AddCommand(newDeliverooSessionCmd(st))
+	cmd.AddCommand(newDeliverooLogoutCmd(st))
 	cmd.AddCommand(newDeliverooHistoryCmd(st))
 	cmd.AddCommand(newDeliverooOrdersCmd(st))
+	cmd.AddCommand(newDeliverooOrderCmd(st))
+	cmd.AddCommand(newDeliverooReorderCmd(st))
 	return cmd
 }
+
+func newFoodoraProvider(st *state, opts provider.FoodoraOptions) (provider.Provider, error) {
+	c, err := newAuthedClient(st)
+	if err != nil {
+		return nil, err
+	}
+	return provider.NewFoodora(c, foodoraOptions(st, opts)), nil
+}
+
+// foodoraOptions fills in the market currency for orders that don't carry one.
+func foodoraOptions(st *state, opts provider.FoodoraOptions) provider.FoodoraOptions {
+	if opts.Currency == "" {
+		opts.Currency = countryCurrency(st.foodora().TargetCountryISO)
+	}
+	return opts
+}
+
+func newDeliverooProvider(st *state, f deliverooClientFlags, opts provider.DeliverooOptions) (provider.Provider, error) {
+	c, err := newDeliverooClient(st, f)
+	if err != nil {
+		return nil, err
+	}
+	return provider.NewDeliveroo(c, opts), nil
+}
+
+type providerEntry struct {
+	name       string
+	configured func(st *state) bool
+	open       func(st *state) (provider.Provider, error)
+}
+
+// providerRegistry lists the providers used by the cross-provider commands, in display order.
+var providerRegistry = []providerEntry{
+	{
+		name: provider.FoodoraName,
+		configured: func(st *state) bool {
+			cfg := st.foodora()
+			return cfg.BaseURL != "" && cfg.HasSession()
+		},
+		open: func(st *state) (provider.Provider, error) {
+			return newFoodoraProvider(st, provider.FoodoraOptions{})
+		},
+	},
+	{
+		name: provider.DeliverooName,
+		configured: func(st *state) bool {
+			return strings.TrimSpace(os.Getenv("DELIVEROO_BEARER_TOKEN")) != "" || st.deliveroo().HasSession()
+		},
+		open: func(st *state) (provider.Provider, error) {
+			return newDeliverooProvider(st, deliverooClientFlags{}, provider.DeliverooOptions{})
+		},
+	},
+}
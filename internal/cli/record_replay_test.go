@@ -0,0 +1,80 @@
+package cli
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRecordReplay(t *testing.T) {
+	dir := t.TempDir()
+	cassetteDir := filepath.Join(dir, "cassette")
+
+	var requests int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/orders/o1"):
+			_, _ = w.Write([]byte(`{"id":"o1","status":"delivered","items":[{"name":"Chai","quantity":2}],"restaurant":{"name":"Dishoom","address":"7 Secret Street"}}`))
+		default:
+			_, _ = w.Write([]byte(`{"count":1,"orders":[{"id":"o1","status":"delivered","submitted_at":"2025-12-20T12:00:00Z","restaurant":{"name":"Dishoom"}}]}`))
+		}
+	}))
+	defer srv.Close()
+
+	recCfg := filepath.Join(dir, "record.json")
+	if _, _, err := runCLI(recCfg, []string{"deliveroo", "config", "set", "--base-url", srv.URL}, ""); err != nil {
+		t.Fatalf("config set: %v", err)
+	}
+	setEnv(t, "DELIVEROO_BEARER_TOKEN", "secret-bearer")
+	liveHistory, _, err := runCLI(recCfg, []string{"--record", cassetteDir, "deliveroo", "history"}, "")
+	if err != nil {
+		t.Fatalf("record history: %v", err)
+	}
+	liveOrder, _, err := runCLI(recCfg, []string{"--record", cassetteDir, "deliveroo", "order", "o1"}, "")
+	if err != nil {
+		t.Fatalf("record order: %v", err)
+	}
+
+	files, _ := filepath.Glob(filepath.Join(cassetteDir, "*.json"))
+	if len(files) != 2 || requests != 2 {
+		t.Fatalf("files=%v requests=%d", files, requests)
+	}
+	for _, f := range files {
+		b, _ := os.ReadFile(f)
+		if strings.Contains(string(b), "secret-bearer") || strings.Contains(string(b), "Secret Street") {
+			t.Fatalf("%s not redacted:\n%s", filepath.Base(f), b)
+		}
+	}
+
+	// Replay: fresh config, no credentials, no network.
+	srv.Close()
+	setEnv(t, "DELIVEROO_BEARER_TOKEN", "")
+	playCfg := filepath.Join(dir, "replay.json")
+	if _, _, err := runCLI(playCfg, []string{"deliveroo", "config", "set", "--base-url", srv.URL}, ""); err != nil {
+		t.Fatalf("config set: %v", err)
+	}
+	out, _, err := runCLI(playCfg, []string{"--replay", cassetteDir, "deliveroo", "history"}, "")
+	if err != nil || out != liveHistory {
+		t.Fatalf("replay history: out=%q want %q err=%v", out, liveHistory, err)
+	}
+	out, _, err = runCLI(playCfg, []string{"--replay", cassetteDir, "deliveroo", "order", "o1"}, "")
+	if err != nil {
+		t.Fatalf("replay order: %v", err)
+	}
+	// Same receipt, except the address was redacted in the cassette.
+	if !strings.Contains(out, "- 2x Chai") || !strings.Contains(out, "restaurant_address=***") || !strings.Contains(liveOrder, "restaurant_address=7 Secret Street") {
+		t.Fatalf("replay order=%q live=%q", out, liveOrder)
+	}
+
+	if _, _, err := runCLI(playCfg, []string{"--replay", cassetteDir, "deliveroo", "order", "o2"}, ""); err == nil || !strings.Contains(err.Error(), "no recorded response") {
+		t.Fatalf("expected cassette miss, got %v", err)
+	}
+	if _, _, err := runCLI(playCfg, []string{"--record", cassetteDir, "--replay", cassetteDir, "deliveroo", "history"}, ""); err == nil {
+		t.Fatalf("expected --record/--replay conflict")
+	}
+}
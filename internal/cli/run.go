@@ -6,6 +6,9 @@ import (
 	"os"
 
 	"github.com/spf13/cobra"
+
+	"github.com/steipete/ordercli/internal/deliveroo"
+	"github.com/steipete/ordercli/internal/transport"
 )
 
 func Run(ctx context.Context, args []string) error {
@@ -14,24 +17,59 @@ func Run(ctx context.Context, args []string) error {
 	root.SetContext(ctx)
 	if err := root.Execute(); err != nil {
 		fmt.Fprintln(os.Stderr, err)
+		if hint := errorHint(err); hint != "" {
+			fmt.Fprintln(os.Stderr, "hint: "+hint)
+		}
 		return err
 	}
 	return nil
 }
 
+// errorHint suggests the next step for failures a retry won't fix.
+func errorHint(err error) string {
+	switch deliveroo.KindOf(err) {
+	case deliveroo.KindUnauthorized:
+		return "the deliveroo session is missing or expired; run `ordercli deliveroo login` (or `deliveroo session chrome`)"
+	case deliveroo.KindBotChallenge:
+		return "deliveroo answered with a bot challenge; open the site in Chrome, then `ordercli deliveroo session chrome`"
+	}
+	return ""
+}
+
 func newRoot() *cobra.Command {
 	var cfgPath string
+	var profile string
+	var record string
+	var replay string
 
 	cmd := &cobra.Command{
 		Use:   "ordercli",
 		Short: "multi-provider order CLI",
 	}
 	cmd.PersistentFlags().StringVar(&cfgPath, "config", "", "config path (default: OS config dir)")
+	cmd.PersistentFlags().StringVar(&profile, "profile", "", "config profile (env: "+profileEnv+"; default: set by profile use)")
 
-	st := &state{}
+	st := &state{httpOpts: transport.Defaults()}
+	cmd.PersistentFlags().IntVar(&st.httpOpts.Retries, "http-retries", st.httpOpts.Retries, "retries for failed GET requests (429, 5xx, network errors); 0 disables")
+	cmd.PersistentFlags().Float64Var(&st.httpOpts.Rate, "http-rate", st.httpOpts.Rate, "max requests per second per host; 0 disables the budget")
+	cmd.PersistentFlags().DurationVar(&st.httpOpts.MaxWait, "http-max-wait", st.httpOpts.MaxWait, "longest Retry-After / ratelimit-reset pause to honor; longer ones fail")
+	cmd.PersistentFlags().DurationVar(&st.httpOpts.Timeout, "http-timeout", st.httpOpts.Timeout, "timeout per HTTP attempt")
+	cmd.PersistentFlags().StringVar(&record, "record", "", "record all HTTP requests/responses (redacted) into this cassette directory")
+	cmd.PersistentFlags().StringVar(&replay, "replay", "", "replay HTTP responses from this cassette directory instead of the network (as recorded, i.e. with redacted values)")
 	cmd.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
 		st.configPath = cfgPath
-		return st.load()
+		st.profile = profile
+		st.stderr = cmd.ErrOrStderr()
+		if err := st.load(); err != nil {
+			return err
+		}
+		if err := st.initHTTP(record, replay); err != nil {
+			return err
+		}
+		if isProfileCmd(cmd) {
+			return nil
+		}
+		return st.checkProfile()
 	}
 	cmd.PersistentPostRunE = func(cmd *cobra.Command, args []string) error {
 		return st.save()
@@ -39,6 +77,15 @@ func newRoot() *cobra.Command {
 
 	cmd.AddCommand(newFoodoraCmd(st))
 	cmd.AddCommand(newDeliverooCmd(st))
+	cmd.AddCommand(newTimelineHistoryCmd(st))
+	cmd.AddCommand(newTimelineOrdersCmd(st))
+	cmd.AddCommand(newSyncCmd(st))
+	cmd.AddCommand(newStatsCmd(st))
+	cmd.AddCommand(newCalendarCmd(st))
+	cmd.AddCommand(newServeCmd(st))
+	cmd.AddCommand(newMCPCmd(st))
+	cmd.AddCommand(newProfileCmd(st))
+	cmd.AddCommand(newSecretsCmd(st))
 
 	return cmd
 }
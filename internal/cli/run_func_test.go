@@ -2,8 +2,13 @@ package cli
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"path/filepath"
+	"strings"
 	"testing"
+
+	"github.com/steipete/ordercli/internal/deliveroo"
 )
 
 func TestRun_Help(t *testing.T) {
@@ -12,3 +17,16 @@ func TestRun_Help(t *testing.T) {
 		t.Fatalf("Run: %v", err)
 	}
 }
+
+func TestErrorHint(t *testing.T) {
+	err := fmt.Errorf("history: %w", &deliveroo.HTTPError{StatusCode: 401, Kind: deliveroo.KindUnauthorized})
+	if hint := errorHint(err); !strings.Contains(hint, "deliveroo login") {
+		t.Fatalf("hint=%q", hint)
+	}
+	if hint := errorHint(&deliveroo.HTTPError{StatusCode: 403, Kind: deliveroo.KindBotChallenge}); !strings.Contains(hint, "session chrome") {
+		t.Fatalf("hint=%q", hint)
+	}
+	if hint := errorHint(errors.New("boom")); hint != "" {
+		t.Fatalf("hint=%q", hint)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net/http"
 	"net/url"
 	"os"
 	"strings"
@@ -40,7 +41,7 @@ func (s *state) resolveClientSecret(ctx context.Context, clientID string) (resol
 		return resolvedSecret{Secret: v, FromEnv: true}, nil
 	}
 
-	secret, err := fetchClientSecretFromRemoteConfig(ctx, s.firebaseConfig(), s.remoteConfigKeyCandidates(), clientID)
+	secret, err := fetchClientSecretFromRemoteConfig(ctx, s.httpClient(), s.firebaseConfig(), s.remoteConfigKeyCandidates(), clientID)
 	if err != nil {
 		return resolvedSecret{}, err
 	}
@@ -64,7 +65,7 @@ func (s *state) forceFetchClientSecret(ctx context.Context, clientID string) (re
 		clientID = "android"
 	}
 
-	secret, err := fetchClientSecretFromRemoteConfig(ctx, s.firebaseConfig(), s.remoteConfigKeyCandidates(), clientID)
+	secret, err := fetchClientSecretFromRemoteConfig(ctx, s.httpClient(), s.firebaseConfig(), s.remoteConfigKeyCandidates(), clientID)
 	if err != nil {
 		return resolvedSecret{}, err
 	}
@@ -124,8 +125,9 @@ func (s *state) remoteConfigKeyCandidates() []string {
 	return out
 }
 
-func fetchClientSecretFromRemoteConfig(ctx context.Context, cfg firebase.APKFirebaseConfig, keys []string, clientID string) (string, error) {
+func fetchClientSecretFromRemoteConfig(ctx context.Context, hc *http.Client, cfg firebase.APKFirebaseConfig, keys []string, clientID string) (string, error) {
 	rc := firebase.NewRemoteConfigClient(cfg)
+	rc.SetHTTPClient(hc)
 	resp, err := rc.Fetch(ctx)
 	if err != nil {
 		return "", err
@@ -0,0 +1,251 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steipete/ordercli/internal/config"
+	"github.com/steipete/ordercli/internal/secrets"
+	"golang.org/x/term"
+)
+
+// secretsPassphraseEnv supplies the passphrase of the encrypted secrets file.
+const secretsPassphraseEnv = "ORDERCLI_SECRETS_PASSPHRASE"
+
+// openSecretStore is replaced in tests (file-backed fake keyring).
+var openSecretStore = secrets.Open
+
+func (s *state) openSecrets(opts config.SecretsConfig) (secrets.Store, error) {
+	if s.secretStore != nil && s.secretOpts == opts {
+		return s.secretStore, nil
+	}
+	return openSecretStore(secrets.Options{Backend: opts.Backend, Path: s.secretsPath(opts), Passphrase: s.secretsPassphrase})
+}
+
+func (s *state) secretsPassphrase() (string, error) {
+	if p := os.Getenv(secretsPassphraseEnv); p != "" {
+		return p, nil
+	}
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		return "", fmt.Errorf("secrets file is encrypted: set %s", secretsPassphraseEnv)
+	}
+	fmt.Fprint(s.errOut(), "secrets passphrase: ")
+	b, err := term.ReadPassword(int(os.Stdin.Fd()))
+	fmt.Fprintln(s.errOut())
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(b)), nil
+}
+
+// resolveSecrets replaces secret references in the loaded config with their values. Only a
+// secret the backend reports as missing is dropped; any other read failure (locked keyring,
+// cancelled prompt) fails the command, so a later save can't lose the stored value.
+func (s *state) resolveSecrets() error {
+	s.secretStore, s.storedSecrets = nil, map[string]string{}
+	if s.cfg.Secrets == nil {
+		return nil
+	}
+	opts := *s.cfg.Secrets
+	for _, f := range s.cfg.SecretFields() {
+		key, ok := config.ParseSecretRef(f.Get())
+		if !ok {
+			continue
+		}
+		store, err := s.openSecrets(opts)
+		if err != nil {
+			return err
+		}
+		s.secretStore, s.secretOpts = store, opts
+
+		v, err := store.Get(key)
+		if errors.Is(err, secrets.ErrNotFound) {
+			fmt.Fprintf(s.errOut(), "warning: secret %s missing from %s backend\n", key, opts.Backend)
+			f.Set("")
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("secret %s: %w", key, err)
+		}
+		f.Set(v)
+		s.storedSecrets[key] = v
+	}
+	return nil
+}
+
+// saveWithSecrets writes the config with secrets moved to the configured backend (only
+// changed values are written), then drops values the config no longer references: cleared
+// tokens, removed profiles, or everything after switching backends.
+func (s *state) saveWithSecrets() error {
+	var store secrets.Store
+	var opts config.SecretsConfig
+	if s.cfg.Secrets != nil {
+		opts = *s.cfg.Secrets
+		var err error
+		if store, err = s.openSecrets(opts); err != nil {
+			return err
+		}
+	}
+	sameStore := store != nil && store == s.secretStore
+
+	fields := s.cfg.SecretFields()
+	values := make([]string, len(fields))
+	written := map[string]string{}
+	for i, f := range fields {
+		v := f.Get()
+		values[i] = v
+		if store == nil || v == "" {
+			continue
+		}
+		if old, ok := s.storedSecrets[f.Key]; !sameStore || !ok || old != v {
+			if err := store.Set(f.Key, v); err != nil {
+				return err
+			}
+		}
+		written[f.Key] = v
+	}
+
+	for _, f := range fields {
+		if _, ok := written[f.Key]; ok {
+			f.Set(config.SecretRef(f.Key))
+		}
+	}
+	err := config.Save(s.configPath, s.cfg)
+	for i, f := range fields {
+		f.Set(values[i])
+	}
+	if err != nil {
+		return err
+	}
+
+	if s.secretStore != nil {
+		for key := range s.storedSecrets {
+			if _, ok := written[key]; ok && sameStore {
+				continue
+			}
+			if err := s.secretStore.Delete(key); err != nil {
+				fmt.Fprintf(s.errOut(), "warning: %v\n", err)
+			}
+		}
+	}
+	s.secretStore, s.secretOpts, s.storedSecrets = store, opts, written
+	return nil
+}
+
+// deleteSecrets removes keys from the configured backend, also ones whose values were never
+// read (saveWithSecrets only cleans up what it loaded).
+func (s *state) deleteSecrets(keys []string) error {
+	if s.cfg.Secrets == nil || len(keys) == 0 {
+		return nil
+	}
+	store, err := s.openSecrets(*s.cfg.Secrets)
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for _, key := range keys {
+		if err := store.Delete(key); err != nil {
+			errs = append(errs, err)
+		}
+		delete(s.storedSecrets, key)
+	}
+	return errors.Join(errs...)
+}
+
+func newSecretsCmd(st *state) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "secrets",
+		Short: "Choose where tokens, client secrets and cookies are stored",
+	}
+	cmd.AddCommand(newSecretsStatusCmd(st))
+	cmd.AddCommand(newSecretsMigrateCmd(st))
+	return cmd
+}
+
+func newSecretsStatusCmd(st *state) *cobra.Command {
+	return &cobra.Command{
+		Use:   "status",
+		Short: "Show the secrets backend and how many secrets it holds",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			out := cmd.OutOrStdout()
+			if sc := st.cfg.Secrets; sc != nil {
+				fmt.Fprintf(out, "backend=%s\n", sc.Backend)
+				if sc.Backend == secrets.BackendFile {
+					fmt.Fprintf(out, "path=%s\n", st.secretsPath(*sc))
+				}
+			} else {
+				fmt.Fprintln(out, "backend=config (plaintext)")
+			}
+			fmt.Fprintf(out, "secrets=%d\n", countSecrets(st.cfg))
+		},
+	}
+}
+
+func newSecretsMigrateCmd(st *state) *cobra.Command {
+	var to string
+	var path string
+
+	cmd := &cobra.Command{
+		Use:   "migrate",
+		Short: "Move secrets to the keyring, an encrypted file, or back into the config",
+		Long: "Move secrets to the OS keyring (--to keyring), a passphrase-encrypted file (--to file;\n" +
+			"passphrase from " + secretsPassphraseEnv + " or a prompt) or back into the config file\n" +
+			"(--to config). The config file keeps only references.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var next *config.SecretsConfig
+			switch to {
+			case secrets.BackendKeyring:
+				if path != "" {
+					return errors.New("--path only applies to --to file")
+				}
+				next = &config.SecretsConfig{Backend: to}
+			case secrets.BackendFile:
+				next = &config.SecretsConfig{Backend: to, Path: path}
+			case "config":
+				if path != "" {
+					return errors.New("--path only applies to --to file")
+				}
+			default:
+				return fmt.Errorf("unknown --to %q (want keyring, file or config)", to)
+			}
+			st.cfg.Secrets = next
+			st.markDirty()
+			if err := st.save(); err != nil {
+				return err
+			}
+			where := to
+			if next != nil && next.Backend == secrets.BackendFile {
+				where = st.secretsPath(*next)
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "moved %d secrets to %s\n", countSecrets(st.cfg), where)
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&to, "to", "", "keyring, file or config")
+	cmd.Flags().StringVar(&path, "path", "", "encrypted secrets file (default: secrets.enc next to the config file)")
+	_ = cmd.MarkFlagRequired("to")
+	return cmd
+}
+
+func (s *state) secretsPath(opts config.SecretsConfig) string {
+	if opts.Path != "" {
+		return opts.Path
+	}
+	return filepath.Join(filepath.Dir(s.configPath), "secrets.enc")
+}
+
+func countSecrets(cfg config.Config) int {
+	n := 0
+	for _, f := range cfg.SecretFields() {
+		if f.Get() != "" {
+			n++
+		}
+	}
+	return n
+}
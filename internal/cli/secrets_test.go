@@ -0,0 +1,210 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/steipete/ordercli/internal/config"
+	"github.com/steipete/ordercli/internal/secrets"
+)
+
+// fakeKeyring is a file-backed stand-in for the OS keyring.
+type fakeKeyring struct{ path string }
+
+func (k fakeKeyring) load() map[string]string {
+	m := map[string]string{}
+	if b, err := os.ReadFile(k.path); err == nil {
+		_ = json.Unmarshal(b, &m)
+	}
+	return m
+}
+
+func (k fakeKeyring) write(m map[string]string) error {
+	b, _ := json.Marshal(m)
+	return os.WriteFile(k.path, b, 0o600)
+}
+
+func (k fakeKeyring) Get(key string) (string, error) {
+	v, ok := k.load()[key]
+	if !ok {
+		return "", secrets.ErrNotFound
+	}
+	return v, nil
+}
+
+func (k fakeKeyring) Set(key, value string) error {
+	m := k.load()
+	m[key] = value
+	return k.write(m)
+}
+
+func (k fakeKeyring) Delete(key string) error {
+	m := k.load()
+	delete(m, key)
+	return k.write(m)
+}
+
+func useFakeKeyring(t *testing.T) fakeKeyring {
+	t.Helper()
+	k := fakeKeyring{path: filepath.Join(t.TempDir(), "keyring.json")}
+	old := openSecretStore
+	openSecretStore = func(opts secrets.Options) (secrets.Store, error) {
+		if opts.Backend == secrets.BackendKeyring {
+			return k, nil
+		}
+		return old(opts)
+	}
+	t.Cleanup(func() { openSecretStore = old })
+	return k
+}
+
+func TestSecretsMigrate_Keyring(t *testing.T) {
+	keyring := useFakeKeyring(t)
+	cfgPath := filepath.Join(t.TempDir(), "config.json")
+	fd := newFoodoraTestServer(t)
+	defer fd.Close()
+
+	cfg := config.New()
+	f := cfg.Foodora()
+	f.BaseURL = fd.URL + "/"
+	f.AccessToken = "access"
+	f.RefreshToken = "refresh"
+	f.ClientSecret = "client-secret"
+	f.CookiesByHost = map[string]string{"www.foodora.at": "cf_clearance=x"}
+	if err := config.Save(cfgPath, cfg); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+
+	out, _, err := runCLI(cfgPath, []string{"secrets", "migrate", "--to", "keyring"}, "")
+	if err != nil || !strings.Contains(out, "moved 4 secrets to keyring") {
+		t.Fatalf("migrate: err=%v out=%s", err, out)
+	}
+	b, _ := os.ReadFile(cfgPath)
+	for _, plain := range []string{`"access"`, `"refresh"`, "client-secret", "cf_clearance"} {
+		if strings.Contains(string(b), plain) {
+			t.Fatalf("config still holds %s:\n%s", plain, b)
+		}
+	}
+	if !strings.Contains(string(b), `"secret:default/foodora/access_token"`) {
+		t.Fatalf("missing reference:\n%s", b)
+	}
+	if got := keyring.load(); got["default/foodora/cookie/www.foodora.at"] != "cf_clearance=x" || got["default/foodora/client_secret"] != "client-secret" {
+		t.Fatalf("keyring=%v", got)
+	}
+
+	// Commands see the real values.
+	out, _, err = runCLI(cfgPath, []string{"foodora", "history"}, "")
+	if err != nil || !strings.Contains(out, "HIST-1") {
+		t.Fatalf("history: err=%v out=%s", err, out)
+	}
+	out, _, _ = runCLI(cfgPath, []string{"secrets", "status"}, "")
+	if out != "backend=keyring\nsecrets=4\n" {
+		t.Fatalf("status=%q", out)
+	}
+
+	// Cleared tokens disappear from the keyring.
+	if _, _, err := runCLI(cfgPath, []string{"foodora", "logout"}, ""); err != nil {
+		t.Fatalf("logout: %v", err)
+	}
+	if got := keyring.load(); got["default/foodora/access_token"] != "" || got["default/foodora/client_secret"] != "client-secret" {
+		t.Fatalf("keyring after logout=%v", got)
+	}
+
+	// Back to plaintext: values return to the config, the keyring is emptied.
+	if _, _, err := runCLI(cfgPath, []string{"secrets", "migrate", "--to", "config"}, ""); err != nil {
+		t.Fatalf("migrate back: %v", err)
+	}
+	got, err := config.Load(cfgPath)
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if got.Secrets != nil || got.Foodora().ClientSecret != "client-secret" || got.Foodora().CookiesByHost["www.foodora.at"] != "cf_clearance=x" {
+		t.Fatalf("config=%+v", got.Foodora())
+	}
+	if left := keyring.load(); len(left) != 0 {
+		t.Fatalf("keyring not emptied: %v", left)
+	}
+}
+
+func TestSecretsMigrate_EncryptedFile(t *testing.T) {
+	dir := t.TempDir()
+	cfgPath := filepath.Join(dir, "config.json")
+	fd := newFoodoraTestServer(t)
+	defer fd.Close()
+	writeLoggedInFoodoraConfig(t, cfgPath, fd.URL+"/")
+	setEnv(t, secretsPassphraseEnv, "hunter2")
+
+	if _, _, err := runCLI(cfgPath, []string{"secrets", "migrate", "--to", "file"}, ""); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+	enc, err := os.ReadFile(filepath.Join(dir, "secrets.enc"))
+	if err != nil {
+		t.Fatalf("read secrets file: %v", err)
+	}
+	if strings.Contains(string(enc), "refresh") {
+		t.Fatalf("plaintext in secrets file")
+	}
+
+	out, _, err := runCLI(cfgPath, []string{"foodora", "orders"}, "")
+	if err != nil || !strings.Contains(out, "OC-1") {
+		t.Fatalf("orders: err=%v out=%s", err, out)
+	}
+
+	setEnv(t, secretsPassphraseEnv, "wrong")
+	if _, _, err := runCLI(cfgPath, []string{"foodora", "orders"}, ""); err == nil || !strings.Contains(err.Error(), "wrong passphrase") {
+		t.Fatalf("want wrong passphrase error, got %v", err)
+	}
+	setEnv(t, secretsPassphraseEnv, "hunter2")
+	if _, _, err := runCLI(cfgPath, []string{"secrets", "migrate", "--to", "vault"}, ""); err == nil || !strings.Contains(err.Error(), "unknown --to") {
+		t.Fatalf("want unknown backend error, got %v", err)
+	}
+}
+
+// lockedKeyring fails every read the way a locked keyring or a cancelled prompt does.
+type lockedKeyring struct{ fakeKeyring }
+
+func (lockedKeyring) Get(key string) (string, error) {
+	return "", errors.New("keyring: read " + key + ": exit status 1: Cannot get secret of a locked object")
+}
+
+func TestSecrets_UnreadableKeepsReference(t *testing.T) {
+	keyring := useFakeKeyring(t)
+	cfgPath := filepath.Join(t.TempDir(), "config.json")
+
+	cfg := config.New()
+	cfg.Secrets = &config.SecretsConfig{Backend: secrets.BackendKeyring}
+	f := cfg.Foodora()
+	f.AccessToken = config.SecretRef("default/foodora/access_token")
+	f.RefreshToken = config.SecretRef("default/foodora/refresh_token")
+	if err := config.Save(cfgPath, cfg); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+	if err := keyring.write(map[string]string{"default/foodora/access_token": "access"}); err != nil {
+		t.Fatalf("keyring: %v", err)
+	}
+
+	// A missing secret is reported on the command's stderr and dropped.
+	_, errOut, err := runCLI(cfgPath, []string{"foodora", "config", "show"}, "")
+	if err != nil || !strings.Contains(errOut, "warning: secret default/foodora/refresh_token missing from keyring backend") {
+		t.Fatalf("missing: err=%v errOut=%q", err, errOut)
+	}
+
+	// A read failure fails the command; nothing is saved or deleted.
+	before, _ := os.ReadFile(cfgPath)
+	prev := openSecretStore
+	openSecretStore = func(secrets.Options) (secrets.Store, error) { return lockedKeyring{keyring}, nil }
+	t.Cleanup(func() { openSecretStore = prev })
+	if _, _, err := runCLI(cfgPath, []string{"foodora", "config", "set", "--country", "AT"}, ""); err == nil || !strings.Contains(err.Error(), "locked") {
+		t.Fatalf("want locked error, got %v", err)
+	}
+	if after, _ := os.ReadFile(cfgPath); string(after) != string(before) {
+		t.Fatalf("config changed:\n%s", after)
+	}
+	if got := keyring.load(); got["default/foodora/access_token"] != "access" {
+		t.Fatalf("keyring=%v", got)
+	}
+}
@@ -0,0 +1,313 @@
+package cli
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steipete/ordercli/internal/foodora"
+	"github.com/steipete/ordercli/internal/provider"
+)
+
+func newServeCmd(st *state) *cobra.Command {
+	var listen string
+	var token string
+
+	cmd := &cobra.Command{
+		Use:   "serve",
+		Short: "Serve foodora history, orders and tracking as a local JSON API",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if token == "" {
+				token = os.Getenv("ORDERCLI_SERVE_TOKEN")
+			}
+			loopback := isLoopbackListen(listen)
+			if !loopback && strings.TrimSpace(token) == "" {
+				return fmt.Errorf("refusing to serve order history and addresses on %s without a token; set --token or ORDERCLI_SERVE_TOKEN, or listen on 127.0.0.1", listen)
+			}
+			ln, err := net.Listen("tcp", listen)
+			if err != nil {
+				return err
+			}
+			api := newAPIServer(st, token)
+			// Bound to loopback, only local names are accepted in Host: a web page can't
+			// reach the API by rebinding its own domain to 127.0.0.1.
+			api.loopbackOnly = loopback
+			srv := &http.Server{
+				Handler:           api.handler(),
+				ReadHeaderTimeout: 10 * time.Second,
+			}
+
+			ctx := cmd.Context()
+			done := make(chan struct{})
+			go func() {
+				defer close(done)
+				<-ctx.Done()
+				shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+				defer cancel()
+				_ = srv.Shutdown(shutdownCtx)
+			}()
+
+			fmt.Fprintf(cmd.OutOrStdout(), "listening on http://%s\n", ln.Addr())
+			if err := srv.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				return err
+			}
+			<-done
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&listen, "listen", "127.0.0.1:8080", "listen address (anything but loopback requires --token)")
+	cmd.Flags().StringVar(&token, "token", "", "require an Authorization: Bearer <token> header (env: ORDERCLI_SERVE_TOKEN)")
+	return cmd
+}
+
+type apiServer struct {
+	*foodoraSession
+	token        string
+	loopbackOnly bool
+}
+
+func newAPIServer(st *state, token string) *apiServer {
+	return &apiServer{foodoraSession: &foodoraSession{st: st}, token: strings.TrimSpace(token)}
+}
+
+func (s *apiServer) handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /healthz", func(w http.ResponseWriter, r *http.Request) {
+		writeAPIJSON(w, http.StatusOK, map[string]any{"ok": true})
+	})
+	mux.HandleFunc("GET /v1/history", s.handleHistory)
+	mux.HandleFunc("GET /v1/history/{code}", s.handleHistoryShow)
+	mux.HandleFunc("GET /v1/orders", s.handleOrders)
+	mux.HandleFunc("GET /v1/orders/{code}", s.handleOrder)
+	mux.HandleFunc("GET /v1/reorder/{code}", s.handleReorderPreview)
+	return s.checkHost(s.auth(mux))
+}
+
+func (s *apiServer) checkHost(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if s.loopbackOnly && !isLoopbackHost(r.Host) {
+			writeAPIError(w, http.StatusMisdirectedRequest, fmt.Errorf("host %q not allowed", r.Host))
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (s *apiServer) auth(next http.Handler) http.Handler {
+	if s.token == "" {
+		return next
+	}
+	want := []byte("Bearer " + s.token)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if subtle.ConstantTimeCompare([]byte(r.Header.Get("Authorization")), want) != 1 {
+			writeAPIError(w, http.StatusUnauthorized, errors.New("missing or invalid bearer token"))
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// foodoraSession shares one config state between concurrent callers (serve, mcp). mu serializes
+// everything that reads or writes it: client construction, token refresh and saving the config.
+type foodoraSession struct {
+	st *state
+	mu sync.Mutex
+}
+
+// foodoraClient builds an authenticated client. A refreshed token is written to the config
+// right away, since the process may run for days.
+func (s *foodoraSession) foodoraClient() (*foodora.Client, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	c, err := newAuthedClient(s.st)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.st.save(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+func (s *foodoraSession) foodoraProvider() (provider.Provider, error) {
+	c, err := s.foodoraClient()
+	if err != nil {
+		return nil, err
+	}
+	return provider.NewFoodora(c, foodoraOptions(s.st, provider.FoodoraOptions{})), nil
+}
+
+func (s *apiServer) handleHistory(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	limit, err := queryInt(q.Get("limit"), 20)
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, fmt.Errorf("limit: %w", err))
+		return
+	}
+	if limit == 0 {
+		limit = 20
+	}
+	offset, err := queryInt(q.Get("offset"), 0)
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, fmt.Errorf("offset: %w", err))
+		return
+	}
+	p, err := s.foodoraProvider()
+	if err != nil {
+		writeAPIError(w, http.StatusServiceUnavailable, err)
+		return
+	}
+	page, err := p.History(r.Context(), provider.HistoryRequest{Offset: offset, Limit: min(limit, 100)})
+	if err != nil {
+		writeUpstreamError(w, err)
+		return
+	}
+	if page.Orders == nil {
+		page.Orders = []provider.Order{}
+	}
+	writeAPIJSON(w, http.StatusOK, map[string]any{"orders": page.Orders, "total": page.Total, "offset": offset})
+}
+
+func (s *apiServer) handleHistoryShow(w http.ResponseWriter, r *http.Request) {
+	c, err := s.foodoraClient()
+	if err != nil {
+		writeAPIError(w, http.StatusServiceUnavailable, err)
+		return
+	}
+	resp, err := c.OrderHistoryByCode(r.Context(), foodora.OrderHistoryByCodeRequest{
+		OrderCode: r.PathValue("code"),
+		Include:   "order_products,order_details",
+	})
+	if err != nil {
+		writeUpstreamError(w, err)
+		return
+	}
+	if len(resp.Data.Items) == 0 {
+		writeAPIError(w, http.StatusNotFound, provider.ErrNotFound)
+		return
+	}
+	writeAPIJSON(w, http.StatusOK, resp.Data.Items[0])
+}
+
+func (s *apiServer) handleOrders(w http.ResponseWriter, r *http.Request) {
+	p, err := s.foodoraProvider()
+	if err != nil {
+		writeAPIError(w, http.StatusServiceUnavailable, err)
+		return
+	}
+	page, err := p.ActiveOrders(r.Context())
+	if err != nil {
+		writeUpstreamError(w, err)
+		return
+	}
+	if page.Orders == nil {
+		page.Orders = []provider.Order{}
+	}
+	writeAPIJSON(w, http.StatusOK, map[string]any{"orders": page.Orders, "poll_in_sec": int(page.PollInterval / time.Second)})
+}
+
+func (s *apiServer) handleOrder(w http.ResponseWriter, r *http.Request) {
+	c, err := s.foodoraClient()
+	if err != nil {
+		writeAPIError(w, http.StatusServiceUnavailable, err)
+		return
+	}
+	resp, err := c.OrderStatus(r.Context(), r.PathValue("code"))
+	if err != nil {
+		writeUpstreamError(w, err)
+		return
+	}
+	writeAPIJSON(w, http.StatusOK, resp.Data)
+}
+
+// handleReorderPreview never calls the reorder endpoint; it returns the normalized past order.
+func (s *apiServer) handleReorderPreview(w http.ResponseWriter, r *http.Request) {
+	p, err := s.foodoraProvider()
+	if err != nil {
+		writeAPIError(w, http.StatusServiceUnavailable, err)
+		return
+	}
+	d, err := p.ReorderPreview(r.Context(), r.PathValue("code"))
+	if err != nil {
+		if errors.Is(err, provider.ErrNotFound) {
+			writeAPIError(w, http.StatusNotFound, err)
+			return
+		}
+		writeUpstreamError(w, err)
+		return
+	}
+	writeAPIJSON(w, http.StatusOK, d)
+}
+
+func queryInt(s string, def int) (int, error) {
+	if strings.TrimSpace(s) == "" {
+		return def, nil
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("want a non-negative integer, got %q", s)
+	}
+	return n, nil
+}
+
+// isLoopbackListen reports whether a --listen address only accepts local connections; an empty
+// host (":8080") listens on every interface.
+func isLoopbackListen(addr string) bool {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return false
+	}
+	return host != "" && isLoopbackHost(host)
+}
+
+// isLoopbackHost reports whether a Host header (or host) names this machine: localhost or a
+// loopback IP, with or without a port.
+func isLoopbackHost(host string) bool {
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	host = strings.TrimSuffix(strings.Trim(host, "[]"), ".")
+	if strings.EqualFold(host, "localhost") {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// writeUpstreamError passes through not-found and auth failures; everything else is a bad gateway.
+func writeUpstreamError(w http.ResponseWriter, err error) {
+	status := http.StatusBadGateway
+	var he *foodora.HTTPError
+	if errors.As(err, &he) {
+		switch he.StatusCode {
+		case http.StatusNotFound:
+			status = http.StatusNotFound
+		case http.StatusUnauthorized, http.StatusForbidden:
+			status = http.StatusUnauthorized
+		case http.StatusTooManyRequests:
+			status = http.StatusTooManyRequests
+		}
+	}
+	writeAPIError(w, status, err)
+}
+
+func writeAPIError(w http.ResponseWriter, status int, err error) {
+	writeAPIJSON(w, status, map[string]string{"error": err.Error()})
+}
+
+func writeAPIJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = writeJSON(w, v)
+}
@@ -0,0 +1,153 @@
+package cli
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/steipete/ordercli/internal/config"
+)
+
+func TestServeAPI(t *testing.T) {
+	cfgPath := filepath.Join(t.TempDir(), "config.json")
+	fd := newFoodoraTestServer(t)
+	defer fd.Close()
+
+	// Expired token: the first request refreshes it and persists the new one.
+	cfg := config.New()
+	f := cfg.Foodora()
+	f.BaseURL = fd.URL + "/"
+	f.AccessToken = "access"
+	f.RefreshToken = "refresh"
+	f.ExpiresAt = time.Now().Add(-time.Hour)
+	f.ClientSecret = "secret"
+	f.OAuthClientID = "android"
+	if err := config.Save(cfgPath, cfg); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+
+	st := &state{configPath: cfgPath}
+	if err := st.load(); err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	srv := newAPIServer(st, "tok")
+	srv.loopbackOnly = true
+	api := httptest.NewServer(srv.handler())
+	defer api.Close()
+
+	getHost := func(host, path string, auth bool) (int, map[string]any) {
+		t.Helper()
+		req, _ := http.NewRequest(http.MethodGet, api.URL+path, nil)
+		if host != "" {
+			req.Host = host
+		}
+		if auth {
+			req.Header.Set("Authorization", "Bearer tok")
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("GET %s: %v", path, err)
+		}
+		defer resp.Body.Close()
+		if ct := resp.Header.Get("Content-Type"); ct != "application/json" {
+			t.Fatalf("GET %s: content-type=%q", path, ct)
+		}
+		var v map[string]any
+		if err := json.NewDecoder(resp.Body).Decode(&v); err != nil {
+			t.Fatalf("GET %s: decode: %v", path, err)
+		}
+		return resp.StatusCode, v
+	}
+	get := func(path string, auth bool) (int, map[string]any) {
+		t.Helper()
+		return getHost("", path, auth)
+	}
+
+	if code, v := get("/v1/orders", false); code != http.StatusUnauthorized || v["error"] == nil {
+		t.Fatalf("expected 401, got %d %v", code, v)
+	}
+
+	// Concurrent first requests must refresh the token only once and not race on the config.
+	var wg sync.WaitGroup
+	for range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if code, v := get("/v1/orders", true); code != http.StatusOK {
+				t.Errorf("orders: %d %v", code, v)
+			}
+		}()
+	}
+	wg.Wait()
+	saved, err := config.Load(cfgPath)
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if saved.Foodora().AccessToken != "access2" {
+		t.Fatalf("refreshed token not persisted: %q", saved.Foodora().AccessToken)
+	}
+
+	code, v := get("/v1/orders", true)
+	if orders, _ := v["orders"].([]any); code != http.StatusOK || len(orders) != 1 {
+		t.Fatalf("orders: %d %v", code, v)
+	}
+	code, v = get("/v1/history?limit=5", true)
+	if orders, _ := v["orders"].([]any); code != http.StatusOK || len(orders) != 1 || v["total"] != float64(1) {
+		t.Fatalf("history: %d %v", code, v)
+	}
+	code, v = get("/v1/history/HIST-1", true)
+	if code != http.StatusOK || v["order_code"] != "HIST-1" || v["order_products"] == nil {
+		t.Fatalf("history show: %d %v", code, v)
+	}
+	code, v = get("/v1/orders/OC-1", true)
+	if code != http.StatusOK || v["status_messages"] == nil {
+		t.Fatalf("order: %d %v", code, v)
+	}
+	code, v = get("/v1/reorder/HIST-1", true)
+	if lines, _ := v["lines"].([]any); code != http.StatusOK || len(lines) != 1 {
+		t.Fatalf("reorder preview: %d %v", code, v)
+	}
+	code, v = get("/v1/history?limit=0", true)
+	if orders, _ := v["orders"].([]any); code != http.StatusOK || len(orders) != 1 {
+		t.Fatalf("history limit=0: %d %v", code, v)
+	}
+	// DNS rebinding: a foreign Host is turned away even with a valid token.
+	if code, v := getHost("evil.example:8080", "/v1/orders", true); code != http.StatusMisdirectedRequest || v["error"] == nil {
+		t.Fatalf("foreign host: %d %v", code, v)
+	}
+	if code, _ := getHost("localhost:8080", "/healthz", true); code != http.StatusOK {
+		t.Fatalf("localhost: %d", code)
+	}
+	if code, v := get("/v1/history?limit=x", true); code != http.StatusBadRequest || !strings.Contains(v["error"].(string), "limit") {
+		t.Fatalf("bad limit: %d %v", code, v)
+	}
+}
+
+func TestServe_NonLoopbackRequiresToken(t *testing.T) {
+	cfgPath := filepath.Join(t.TempDir(), "config.json")
+	setEnv(t, "ORDERCLI_SERVE_TOKEN", "")
+	for _, listen := range []string{":0", "0.0.0.0:0", "[::]:0"} {
+		if _, _, err := runCLI(cfgPath, []string{"serve", "--listen", listen}, ""); err == nil || !strings.Contains(err.Error(), "without a token") {
+			t.Fatalf("%s: expected refusal, got %v", listen, err)
+		}
+	}
+
+	for addr, want := range map[string]bool{
+		"127.0.0.1:8080": true,
+		"localhost:8080": true,
+		"[::1]:8080":     true,
+		":8080":          false,
+		"0.0.0.0:8080":   false,
+		"192.168.1.2:80": false,
+		"127.0.0.1":      false,
+	} {
+		if got := isLoopbackListen(addr); got != want {
+			t.Fatalf("isLoopbackListen(%q)=%t", addr, got)
+		}
+	}
+}
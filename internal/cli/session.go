@@ -26,7 +26,7 @@ func newSessionCmd(st *state) *cobra.Command {
 }
 
 func newSessionChromeCmd(st *state) *cobra.Command {
-	var profile string
+	var chromeProfile string
 	var cookiePath string
 	var timeout time.Duration
 	var url string
@@ -51,7 +51,7 @@ func newSessionChromeCmd(st *state) *cobra.Command {
 			cacheDir := filepath.Join(filepath.Dir(st.configPath), "chrome-cookies")
 			res, err := chromeLoadCookieHeader(cmd.Context(), chromecookies.Options{
 				TargetURL:          strings.TrimSpace(url),
-				ChromeProfile:      profile,
+				ChromeProfile:      chromeProfile,
 				ExplicitCookiePath: cookiePath,
 				FilterNames:        []string{"token", "refresh_token", "device_token"},
 				Timeout:            timeout,
@@ -107,8 +107,8 @@ func newSessionChromeCmd(st *state) *cobra.Command {
 	}
 
 	cmd.Flags().StringVar(&url, "url", "", "site URL that holds the cookies (e.g. https://www.foodora.at/)")
-	cmd.Flags().StringVar(&profile, "profile", "", "Chrome profile name (Default, Profile 1, ...) or path to profile dir")
-	cmd.Flags().StringVar(&cookiePath, "cookie-path", "", "explicit Cookies DB path or profile dir (overrides --profile)")
+	cmd.Flags().StringVar(&chromeProfile, "chrome-profile", "", "Chrome profile name (Default, Profile 1, ...) or path to profile dir")
+	cmd.Flags().StringVar(&cookiePath, "cookie-path", "", "explicit Cookies DB path or profile dir (overrides --chrome-profile)")
 	cmd.Flags().DurationVar(&timeout, "timeout", 30*time.Second, "cookie read timeout (keychain prompts may need longer)")
 	cmd.Flags().StringVar(&forceClientID, "client-id", "", "override oauth client_id to pair with the refresh token (default: from JWT)")
 	return cmd
@@ -172,6 +172,7 @@ func newSessionRefreshCmd(st *state) *cobra.Command {
 					}
 					return ""
 				}(),
+				HTTPClient: st.httpClient(),
 			})
 			if err != nil {
 				return err
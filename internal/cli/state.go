@@ -2,20 +2,97 @@ package cli
 
 import (
 	"errors"
+	"fmt"
+	"io"
+	"net/http"
 	"os"
+	"path/filepath"
 
+	"github.com/steipete/ordercli/internal/cassette"
 	"github.com/steipete/ordercli/internal/config"
+	"github.com/steipete/ordercli/internal/secrets"
+	"github.com/steipete/ordercli/internal/transport"
 )
 
+// profileEnv selects a profile when --profile isn't given.
+const profileEnv = "ORDERCLI_PROFILE"
+
 type state struct {
 	configPath string
-	cfg        config.Config
-	dirty      bool
+	// profile is the selected profile (--profile, ORDERCLI_PROFILE, the config's current
+	// profile); resolved by load, "" means default.
+	profile string
+	cfg     config.Config
+	dirty   bool
+
+	// secretStore holds the secrets the config file references (as last loaded or saved),
+	// storedSecrets the values it has for us. See secrets.go.
+	secretStore   secrets.Store
+	secretOpts    config.SecretsConfig
+	storedSecrets map[string]string
+
+	// httpOpts comes from the --http-* flags; all clients of a run share one transport, so
+	// the per-host budget holds across providers.
+	httpOpts      transport.Options
+	httpTransport *transport.Transport
+	// replaying is set by --replay: responses come from a cassette, credentials aren't needed.
+	replaying bool
+	// stderr is the running command's error output (warnings, prompts); see errOut.
+	stderr io.Writer
+}
+
+// errOut is where state-level warnings and prompts go: the command's stderr once it runs.
+func (s *state) errOut() io.Writer {
+	if s.stderr != nil {
+		return s.stderr
+	}
+	return os.Stderr
+}
+
+func (s *state) foodora() *config.FoodoraConfig { return s.selected().EnsureFoodora() }
+
+func (s *state) deliveroo() *config.DeliverooConfig { return s.selected().EnsureDeliveroo() }
+
+// providers returns the selected profile's settings, or an error for an unknown profile.
+func (s *state) providers() (*config.Providers, error) {
+	p, ok := s.cfg.Profile(s.profile)
+	if !ok {
+		return nil, fmt.Errorf("unknown profile %q (see `ordercli profile list`)", s.profile)
+	}
+	return p, nil
+}
+
+// selected is the profile checkProfile accepted before the command ran; the profile commands,
+// which skip that check, don't read provider settings.
+func (s *state) selected() *config.Providers {
+	p, err := s.providers()
+	if err != nil {
+		panic("internal: " + err.Error())
+	}
+	return p
 }
 
-func (s *state) foodora() *config.FoodoraConfig { return s.cfg.Foodora() }
+func (s *state) profileName() string {
+	if s.profile == "" {
+		return config.DefaultProfile
+	}
+	return s.profile
+}
 
-func (s *state) deliveroo() *config.DeliverooConfig { return s.cfg.Deliveroo() }
+func (s *state) checkProfile() error {
+	_, err := s.providers()
+	return err
+}
+
+// dataDir holds per-account files (archive, browser profile): the config directory for the
+// default profile, profiles/<name> below it for the others.
+func (s *state) dataDir() string {
+	dir := filepath.Dir(s.configPath)
+	if s.profileName() == config.DefaultProfile {
+		return dir
+	}
+	return filepath.Join(dir, "profiles", s.profile)
+}
 
 func (s *state) load() error {
 	if s.configPath == "" {
@@ -44,7 +121,8 @@ func (s *state) load() error {
 					s.configPath = p
 					s.cfg = cfg
 					s.dirty = true // migrate to new path on exit
-					return nil
+					s.resolveProfile()
+					return s.resolveSecrets()
 				}
 			}
 			s.configPath = p
@@ -55,7 +133,20 @@ func (s *state) load() error {
 		return err
 	}
 	s.cfg = cfg
-	return nil
+	s.resolveProfile()
+	return s.resolveSecrets()
+}
+
+func (s *state) resolveProfile() {
+	if s.profile == "" {
+		s.profile = os.Getenv(profileEnv)
+	}
+	if s.profile == "" {
+		s.profile = s.cfg.CurrentProfile
+	}
+	if s.profile == config.DefaultProfile {
+		s.profile = ""
+	}
 }
 
 func (s *state) save() error {
@@ -65,7 +156,7 @@ func (s *state) save() error {
 	if s.configPath == "" {
 		return errors.New("internal: configPath unset")
 	}
-	if err := config.Save(s.configPath, s.cfg); err != nil {
+	if err := s.saveWithSecrets(); err != nil {
 		return err
 	}
 	s.dirty = false
@@ -73,3 +164,36 @@ func (s *state) save() error {
 }
 
 func (s *state) markDirty() { s.dirty = true }
+
+// initHTTP builds the shared transport, recording to (--record) or replaying from (--replay)
+// a cassette directory.
+func (s *state) initHTTP(record, replay string) error {
+	opts := s.httpOpts
+	switch {
+	case record != "" && replay != "":
+		return errors.New("--record and --replay can't be combined")
+	case record != "":
+		rec, err := cassette.NewRecorder(record, nil)
+		if err != nil {
+			return err
+		}
+		opts.Base = rec
+	case replay != "":
+		p, err := cassette.Load(replay)
+		if err != nil {
+			return err
+		}
+		opts.Base = p
+		opts.Rate = 0 // nothing goes over the network
+		s.replaying = true
+	}
+	s.httpTransport = transport.New(opts)
+	return nil
+}
+
+func (s *state) httpClient() *http.Client {
+	if s.httpTransport == nil {
+		s.httpTransport = transport.New(s.httpOpts)
+	}
+	return s.httpTransport.Client()
+}
@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/steipete/ordercli/internal/config"
+	"github.com/steipete/ordercli/internal/secrets"
 )
 
 func TestStateLoad_MigratesLegacyConfig(t *testing.T) {
@@ -50,6 +51,40 @@ func TestStateLoad_MigratesLegacyConfig(t *testing.T) {
 	}
 }
 
+func TestStateLoad_LegacyConfigResolvesSecrets(t *testing.T) {
+	keyring := useFakeKeyring(t)
+	tmpHome := t.TempDir()
+	withEnvMap(t, map[string]string{
+		"HOME":            tmpHome,
+		"XDG_CONFIG_HOME": filepath.Join(tmpHome, ".config"),
+	})
+
+	legacyPath, err := config.LegacyPathFoodoracli()
+	if err != nil {
+		t.Fatalf("legacy path: %v", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(legacyPath), 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	cfg := config.New()
+	cfg.Secrets = &config.SecretsConfig{Backend: secrets.BackendKeyring}
+	cfg.Foodora().AccessToken = config.SecretRef("default/foodora/access_token")
+	if err := config.Save(legacyPath, cfg); err != nil {
+		t.Fatalf("save legacy: %v", err)
+	}
+	if err := keyring.write(map[string]string{"default/foodora/access_token": "a"}); err != nil {
+		t.Fatalf("keyring: %v", err)
+	}
+
+	var st state
+	if err := st.load(); err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if got := st.foodora().AccessToken; got != "a" {
+		t.Fatalf("access token=%q", got)
+	}
+}
+
 func withEnvMap(t *testing.T, m map[string]string) {
 	t.Helper()
 	old := map[string]string{}
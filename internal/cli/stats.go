@@ -0,0 +1,123 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steipete/ordercli/internal/provider"
+	"github.com/steipete/ordercli/internal/stats"
+)
+
+func newStatsCmd(st *state) *cobra.Command {
+	var limit int
+	var pageSize int
+	var only []string
+	var offline bool
+	var currency string
+	var top int
+	var asJSON bool
+
+	cmd := &cobra.Command{
+		Use:   "stats",
+		Short: "Spending analytics over order history",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var orders []provider.Order
+			var err error
+			if offline {
+				orders, err = archivedOrders(st, limit)
+			} else {
+				orders, err = collectHistory(cmd, st, only, limit, pageSize)
+			}
+			if err != nil {
+				return err
+			}
+
+			if c := strings.TrimSpace(currency); c != "" {
+				orders = slices.DeleteFunc(orders, func(o provider.Order) bool { return !strings.EqualFold(o.Total.Currency, c) })
+			}
+			r := stats.Compute(orders, time.Local)
+			if len(r.Currencies) > 1 {
+				return fmt.Errorf("orders in several currencies (%s) can't be summed; pick one with --currency", strings.Join(r.Currencies, ", "))
+			}
+			if asJSON {
+				return writeJSON(cmd.OutOrStdout(), struct {
+					stats.Report
+					TopVendors []stats.Group `json:"top_vendors"`
+				}{r, r.TopVendors(top)})
+			}
+			printStats(cmd.OutOrStdout(), r, top)
+			return nil
+		},
+	}
+
+	cmd.Flags().IntVar(&limit, "limit", 200, "max orders per provider")
+	cmd.Flags().IntVar(&pageSize, "page-size", 50, "page size per provider request")
+	cmd.Flags().StringSliceVar(&only, "provider", nil, "only query these providers (repeatable; default: all logged-in)")
+	cmd.Flags().BoolVar(&offline, "offline", false, "use the local archive (see ordercli sync)")
+	cmd.Flags().StringVar(&currency, "currency", "", "only orders in this currency (required when orders use several)")
+	cmd.Flags().IntVar(&top, "top", 10, "number of top vendors to show (0 = all)")
+	cmd.Flags().BoolVar(&asJSON, "json", false, "print JSON")
+	return cmd
+}
+
+func archivedOrders(st *state, limit int) ([]provider.Order, error) {
+	a, err := st.loadArchive(provider.FoodoraName)
+	if err != nil {
+		return nil, err
+	}
+	var out []provider.Order
+	for i, e := range a.Orders {
+		if limit > 0 && i >= limit {
+			break
+		}
+		out = append(out, archivedOrder(st, e))
+	}
+	return out, nil
+}
+
+func printStats(out io.Writer, r stats.Report, top int) {
+	fmt.Fprintf(out, "orders=%d total=%.2f avg=%.2f median=%.2f", r.Count, r.Total, r.Average, r.Median)
+	if len(r.Currencies) == 1 {
+		fmt.Fprintf(out, " currency=%s", r.Currencies[0])
+	}
+	if r.Skipped > 0 {
+		fmt.Fprintf(out, " skipped=%d", r.Skipped)
+	}
+	fmt.Fprintln(out)
+	if !r.First.IsZero() {
+		fmt.Fprintf(out, "range=%s..%s\n", r.First.Format("2006-01-02"), r.Last.Format("2006-01-02"))
+	}
+	if r.Count == 0 {
+		return
+	}
+
+	sections := []struct {
+		title  string
+		groups []stats.Group
+	}{
+		{"top vendors", r.TopVendors(top)},
+		{"by provider", r.ByProvider},
+		{"by month", r.ByMonth},
+		{"by week", r.ByWeek},
+		{"by weekday", r.ByWeekday},
+		{"by hour", r.ByHour},
+	}
+	for _, sec := range sections {
+		if len(sec.groups) == 0 {
+			continue
+		}
+		fmt.Fprintf(out, "\n%s:\n", sec.title)
+		tw := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(tw, "KEY\tORDERS\tTOTAL\tAVG\tMEDIAN")
+		for _, g := range sec.groups {
+			fmt.Fprintf(tw, "%s\t%d\t%.2f\t%.2f\t%.2f\n", g.Key, g.Count, g.Total, g.Average, g.Median)
+		}
+		_ = tw.Flush()
+	}
+}
@@ -0,0 +1,76 @@
+package cli
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestStats_LiveHistory(t *testing.T) {
+	cfgPath := filepath.Join(t.TempDir(), "config.json")
+	srv := newFoodoraTestServer(t)
+	defer srv.Close()
+	writeLoggedInFoodoraConfig(t, cfgPath, srv.URL+"/")
+	setEnv(t, "DELIVEROO_BEARER_TOKEN", "")
+
+	out, _, err := runCLI(cfgPath, []string{"stats"}, "")
+	if err != nil {
+		t.Fatalf("stats: %v", err)
+	}
+	if !strings.Contains(out, "orders=1 total=12.30") || !strings.Contains(out, "top vendors:") || !strings.Contains(out, "Test Vendor") {
+		t.Fatalf("unexpected out=%s", out)
+	}
+
+	out, _, err = runCLI(cfgPath, []string{"stats", "--json"}, "")
+	if err != nil {
+		t.Fatalf("stats json: %v", err)
+	}
+	var v struct {
+		Count      int `json:"count"`
+		TopVendors []struct {
+			Key string `json:"key"`
+		} `json:"top_vendors"`
+	}
+	if err := json.Unmarshal([]byte(out), &v); err != nil {
+		t.Fatalf("decode: %v out=%s", err, out)
+	}
+	if v.Count != 1 || len(v.TopVendors) != 1 || v.TopVendors[0].Key != "Test Vendor" {
+		t.Fatalf("unexpected: %#v", v)
+	}
+}
+
+func TestStats_CurrenciesAndCancelled(t *testing.T) {
+	cfgPath := filepath.Join(t.TempDir(), "config.json")
+	fd := newFoodoraTestServer(t)
+	defer fd.Close()
+	writeLoggedInFoodoraConfig(t, cfgPath, fd.URL+"/")
+	if _, _, err := runCLI(cfgPath, []string{"foodora", "config", "set", "--target-iso", "AT"}, ""); err != nil {
+		t.Fatalf("config set: %v", err)
+	}
+
+	dr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"orders":[{"id":"d1","status":"delivered","total":20,"currency_code":"gbp","submitted_at":"2025-12-21T00:00:00Z"},` +
+			`{"id":"d2","status":"cancelled","total":99,"currency_code":"gbp","submitted_at":"2025-12-20T00:00:00Z"}]}`))
+	}))
+	defer dr.Close()
+	if _, _, err := runCLI(cfgPath, []string{"deliveroo", "config", "set", "--base-url", dr.URL}, ""); err != nil {
+		t.Fatalf("config set: %v", err)
+	}
+	setEnv(t, "DELIVEROO_BEARER_TOKEN", "tok")
+
+	// foodora's EUR (the market currency) and deliveroo's GBP aren't added up.
+	if _, _, err := runCLI(cfgPath, []string{"stats"}, ""); err == nil || !strings.Contains(err.Error(), "several currencies (EUR, GBP)") {
+		t.Fatalf("expected mixed currency error, got %v", err)
+	}
+	out, _, err := runCLI(cfgPath, []string{"stats", "--currency", "gbp"}, "")
+	if err != nil {
+		t.Fatalf("stats --currency: %v", err)
+	}
+	if !strings.HasPrefix(out, "orders=1 total=20.00 avg=20.00 median=20.00 currency=GBP skipped=1\n") {
+		t.Fatalf("unexpected out=%s", out)
+	}
+}
@@ -0,0 +1,192 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steipete/ordercli/internal/archive"
+	"github.com/steipete/ordercli/internal/foodora"
+	"github.com/steipete/ordercli/internal/provider"
+)
+
+func newSyncCmd(st *state) *cobra.Command {
+	var full bool
+	var maxNew int
+	var pageSize int
+	var include string
+
+	cmd := &cobra.Command{
+		Use:   "sync",
+		Short: "Sync full foodora order details into the local archive (incremental)",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, err := newAuthedClient(st)
+			if err != nil {
+				return err
+			}
+
+			path := st.archivePath(provider.FoodoraName)
+			a, err := archive.Load(path, provider.FoodoraName)
+			if err != nil {
+				return err
+			}
+
+			added, syncErr := syncFoodoraArchive(cmd.Context(), c, &a, foodoraSyncOptions{
+				Full:     full,
+				MaxNew:   maxNew,
+				PageSize: pageSize,
+				Include:  include,
+				Progress: cmd.ErrOrStderr(),
+			})
+			// Keep whatever we fetched; a partial archive is completed by the next sync.
+			if added > 0 || syncErr == nil {
+				if err := archive.Save(path, a); err != nil {
+					return err
+				}
+			}
+			if syncErr != nil {
+				return syncErr
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "ok new=%d total=%d\n", added, len(a.Orders))
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&full, "full", false, "walk the full history (fills gaps; only fetches details for missing orders)")
+	cmd.Flags().IntVar(&maxNew, "max", 0, "max new orders to fetch this run (0 = no limit)")
+	cmd.Flags().IntVar(&pageSize, "page-size", 20, "history page size (API limit)")
+	cmd.Flags().StringVar(&include, "include", "order_products,order_details", "include fields for order details")
+	return cmd
+}
+
+type foodoraSyncOptions struct {
+	Full     bool
+	MaxNew   int
+	PageSize int
+	Include  string
+	Progress io.Writer
+}
+
+// syncFoodoraArchive pages order_history newest-first and fetches details for unknown orders.
+// Unless Full (or the archive is partial), it stops at the first already-archived order.
+func syncFoodoraArchive(ctx context.Context, c *foodora.Client, a *archive.Archive, opts foodoraSyncOptions) (int, error) {
+	ps := opts.PageSize
+	if ps <= 0 {
+		ps = 20
+	}
+	if ps > 100 {
+		ps = 100
+	}
+	walkAll := opts.Full || a.Partial
+
+	added := 0
+	offset := 0
+	for {
+		resp, err := c.OrderHistory(ctx, foodora.OrderHistoryRequest{Offset: offset, Limit: ps})
+		if err != nil {
+			a.Partial = a.Partial || added > 0
+			return added, err
+		}
+		if len(resp.Data.Items) == 0 {
+			break
+		}
+
+		for _, it := range resp.Data.Items {
+			if it.OrderCode == "" {
+				continue
+			}
+			if a.Has(it.OrderCode) {
+				if walkAll {
+					continue
+				}
+				a.SyncedAt = time.Now().UTC()
+				return added, nil
+			}
+			if opts.MaxNew > 0 && added >= opts.MaxNew {
+				a.Partial = true
+				return added, nil
+			}
+
+			detail, err := c.OrderHistoryByCode(ctx, foodora.OrderHistoryByCodeRequest{
+				OrderCode: it.OrderCode,
+				Include:   opts.Include,
+			})
+			if err != nil {
+				a.Partial = a.Partial || added > 0
+				return added, err
+			}
+			if len(detail.Data.Items) == 0 {
+				if opts.Progress != nil {
+					fmt.Fprintf(opts.Progress, "warning: %s: no details returned; skipped\n", it.OrderCode)
+				}
+				continue
+			}
+
+			raw, err := detail.Data.Items[0].Map()
+			if err != nil {
+				a.Partial = a.Partial || added > 0
+				return added, fmt.Errorf("%s: %w", it.OrderCode, err)
+			}
+			e := archive.Entry{
+				Code:     it.OrderCode,
+				SyncedAt: time.Now().UTC(),
+				Detail:   raw,
+			}
+			if it.ConfirmedDeliveryTime != nil {
+				e.Time = it.ConfirmedDeliveryTime.Date.Time
+			}
+			a.Put(e)
+			added++
+			if opts.Progress != nil {
+				fmt.Fprintf(opts.Progress, "synced %s\n", it.OrderCode)
+			}
+		}
+
+		offset += len(resp.Data.Items)
+		if resp.Data.TotalCount > 0 && offset >= int(resp.Data.TotalCount) {
+			break
+		}
+		if len(resp.Data.Items) < ps {
+			break
+		}
+	}
+
+	a.Partial = false
+	a.SyncedAt = time.Now().UTC()
+	return added, nil
+}
+
+func (s *state) archivePath(providerName string) string {
+	return archive.PathFor(s.dataDir(), providerName)
+}
+
+func (s *state) loadArchive(providerName string) (archive.Archive, error) {
+	a, err := archive.Load(s.archivePath(providerName), providerName)
+	if err != nil {
+		return a, err
+	}
+	if len(a.Orders) == 0 && a.SyncedAt.IsZero() {
+		return a, errors.New("local archive is empty (run `ordercli sync` first)")
+	}
+	return a, nil
+}
+
+// archivedDetail normalizes an archived foodora order detail; amounts without a currency get
+// the market's, as provider.Foodora.Order does online.
+func archivedDetail(st *state, e archive.Entry) provider.OrderDetail {
+	var d provider.OrderDetail
+	if it, err := foodora.OrderHistoryDetailFromMap(e.Detail); err == nil {
+		d = provider.FoodoraOrderDetail(it)
+	}
+	d.Order = mergeOrder(provider.Order{Provider: provider.FoodoraName, ID: e.Code, Time: e.Time}, d.Order)
+	d.DefaultCurrency(foodoraOptions(st, provider.FoodoraOptions{}).Currency)
+	return d
+}
+
+func archivedOrder(st *state, e archive.Entry) provider.Order {
+	return archivedDetail(st, e).Order
+}
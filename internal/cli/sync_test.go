@@ -0,0 +1,130 @@
+package cli
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestSync_IncrementalAndOffline(t *testing.T) {
+	cfgPath := filepath.Join(t.TempDir(), "config.json")
+
+	var mu sync.Mutex
+	codes := []string{"B", "A"} // newest first
+	detailCalls := map[string]int{}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/orders/order_history" {
+			http.NotFound(w, r)
+			return
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		q := r.URL.Query()
+		if code := q.Get("order_code"); code != "" {
+			detailCalls[code]++
+			fmt.Fprintf(w, `{"status":200,"data":{"items":[{"order_code":%q,"vendor":{"name":"Vendor %s"},"current_status":{"message":"delivered"},"total_value":10,"order_products":[{"name":"Soup","quantity":1}]}]}}`, code, code)
+			return
+		}
+		offset, _ := strconv.Atoi(q.Get("offset"))
+		limit, _ := strconv.Atoi(q.Get("limit"))
+		var items []string
+		for i := offset; i < len(codes) && i < offset+limit; i++ {
+			items = append(items, fmt.Sprintf(`{"order_code":%q,"confirmed_delivery_time":{"date":"2025-12-%02dT12:00:00Z"}}`, codes[i], 20-i))
+		}
+		fmt.Fprintf(w, `{"status":200,"data":{"total_count":%d,"items":[%s]}}`, len(codes), strings.Join(items, ","))
+	}))
+	defer srv.Close()
+	writeLoggedInFoodoraConfig(t, cfgPath, srv.URL+"/")
+
+	out, errOut, err := runCLI(cfgPath, []string{"sync", "--page-size", "1"}, "")
+	if err != nil {
+		t.Fatalf("sync: %v err=%s", err, errOut)
+	}
+	if strings.TrimSpace(out) != "ok new=2 total=2" {
+		t.Fatalf("unexpected out=%q", out)
+	}
+
+	mu.Lock()
+	codes = append([]string{"C"}, codes...)
+	mu.Unlock()
+
+	out, _, err = runCLI(cfgPath, []string{"sync"}, "")
+	if err != nil {
+		t.Fatalf("sync 2: %v", err)
+	}
+	if strings.TrimSpace(out) != "ok new=1 total=3" {
+		t.Fatalf("unexpected out=%q", out)
+	}
+	if detailCalls["A"] != 1 || detailCalls["B"] != 1 || detailCalls["C"] != 1 {
+		t.Fatalf("unexpected detail calls: %#v", detailCalls)
+	}
+
+	// Offline reads work without a session.
+	if _, _, err := runCLI(cfgPath, []string{"foodora", "logout"}, ""); err != nil {
+		t.Fatalf("logout: %v", err)
+	}
+	out, _, err = runCLI(cfgPath, []string{"foodora", "history", "--offline"}, "")
+	if err != nil {
+		t.Fatalf("history offline: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	if len(lines) != 3 || !strings.HasPrefix(lines[0], "C\tVendor C\tdelivered\t") {
+		t.Fatalf("unexpected out=%q", out)
+	}
+	out, _, err = runCLI(cfgPath, []string{"foodora", "history", "show", "A", "--offline"}, "")
+	if err != nil {
+		t.Fatalf("history show offline: %v", err)
+	}
+	if !strings.Contains(out, "vendor=Vendor A") || !strings.Contains(out, "- 1x Soup") {
+		t.Fatalf("unexpected out=%q", out)
+	}
+	if _, _, err := runCLI(cfgPath, []string{"foodora", "history", "show", "nope", "--offline"}, ""); err == nil {
+		t.Fatalf("expected missing order error")
+	}
+}
+
+func TestHistoryOffline_EmptyArchive(t *testing.T) {
+	cfgPath := filepath.Join(t.TempDir(), "config.json")
+	_, _, err := runCLI(cfgPath, []string{"foodora", "history", "--offline"}, "")
+	if err == nil || !strings.Contains(err.Error(), "ordercli sync") {
+		t.Fatalf("unexpected err: %v", err)
+	}
+}
+
+func TestOffline_MarketCurrency(t *testing.T) {
+	cfgPath := filepath.Join(t.TempDir(), "config.json")
+
+	// Archived orders name no currency: stats and export fill in the market's, as online.
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("order_code") != "" {
+			_, _ = w.Write([]byte(`{"status":200,"data":{"items":[{"order_code":"A","vendor":{"name":"V"},"current_status":{"message":"delivered"},"confirmed_delivery_time":{"date":"2025-12-20T12:00:00Z"},"total_value":10,"order_products":[{"name":"Soup","quantity":1,"total_price":10}]}]}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"status":200,"data":{"total_count":1,"items":[{"order_code":"A","confirmed_delivery_time":{"date":"2025-12-20T12:00:00Z"}}]}}`))
+	}))
+	defer srv.Close()
+	writeLoggedInFoodoraConfig(t, cfgPath, srv.URL+"/")
+	if _, _, err := runCLI(cfgPath, []string{"foodora", "config", "set", "--target-iso", "AT"}, ""); err != nil {
+		t.Fatalf("config set: %v", err)
+	}
+	if _, errOut, err := runCLI(cfgPath, []string{"sync"}, ""); err != nil {
+		t.Fatalf("sync: %v err=%s", err, errOut)
+	}
+
+	out, _, err := runCLI(cfgPath, []string{"stats", "--offline"}, "")
+	if err != nil || !strings.Contains(out, "currency=EUR") {
+		t.Fatalf("stats: %v out=%q", err, out)
+	}
+	out, _, err = runCLI(cfgPath, []string{"history", "export", "--offline", "--items", "rows"}, "")
+	if err != nil || !strings.Contains(out, "foodora,A,V,delivered,2025-12-20T12:00:00Z,10.00,EUR,") {
+		t.Fatalf("export: %v out=%q", err, out)
+	}
+}
@@ -0,0 +1,226 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/spf13/cobra"
+	"github.com/steipete/ordercli/internal/provider"
+)
+
+func newTimelineHistoryCmd(st *state) *cobra.Command {
+	var limit int
+	var pageSize int
+	var only []string
+	var asJSON bool
+
+	cmd := &cobra.Command{
+		Use:   "history",
+		Short: "List past orders across all logged-in providers (newest first)",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if limit <= 0 {
+				limit = 20
+			}
+			orders, err := collectHistory(cmd, st, only, limit, pageSize)
+			if err != nil {
+				return err
+			}
+			if len(orders) > limit {
+				orders = orders[:limit]
+			}
+
+			out := cmd.OutOrStdout()
+			if asJSON {
+				return writeJSON(out, orders)
+			}
+			if len(orders) == 0 {
+				fmt.Fprintln(out, "no past orders")
+				return nil
+			}
+			for _, o := range orders {
+				fmt.Fprintf(out, "%s\t", o.Provider)
+				printOrderRow(out, o)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().IntVar(&limit, "limit", 20, "max orders to print (merged)")
+	cmd.Flags().IntVar(&pageSize, "page-size", 20, "page size per provider request")
+	cmd.Flags().StringSliceVar(&only, "provider", nil, "only query these providers (repeatable; default: all logged-in)")
+	cmd.Flags().BoolVar(&asJSON, "json", false, "print normalized orders as JSON")
+
+	cmd.AddCommand(newHistoryExportCmd(st))
+	return cmd
+}
+
+func newTimelineOrdersCmd(st *state) *cobra.Command {
+	var only []string
+	var asJSON bool
+
+	cmd := &cobra.Command{
+		Use:   "orders",
+		Short: "List active orders across all logged-in providers",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ps, err := openProviders(st, only, cmd.ErrOrStderr())
+			if err != nil {
+				return err
+			}
+
+			results := fanOut(cmd.Context(), ps, func(ctx context.Context, p provider.Provider) ([]provider.Order, error) {
+				page, err := p.ActiveOrders(ctx)
+				return page.Orders, err
+			})
+			orders, err := mergeResults(cmd.ErrOrStderr(), results)
+			if err != nil {
+				return err
+			}
+
+			out := cmd.OutOrStdout()
+			if asJSON {
+				return writeJSON(out, orders)
+			}
+			if len(orders) == 0 {
+				fmt.Fprintln(out, "no active orders")
+				return nil
+			}
+			for _, o := range orders {
+				fmt.Fprintf(out, "%s\t%s\t%s\t%s\n", o.Provider, o.ID, o.Vendor.Name, o.Status)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringSliceVar(&only, "provider", nil, "only query these providers (repeatable; default: all logged-in)")
+	cmd.Flags().BoolVar(&asJSON, "json", false, "print normalized orders as JSON")
+	return cmd
+}
+
+// collectHistory fetches up to limit orders per provider in parallel and merges them newest first.
+func collectHistory(cmd *cobra.Command, st *state, only []string, limit, pageSize int) ([]provider.Order, error) {
+	ps, err := openProviders(st, only, cmd.ErrOrStderr())
+	if err != nil {
+		return nil, err
+	}
+	return historyFromProviders(cmd, ps, limit, pageSize)
+}
+
+func historyFromProviders(cmd *cobra.Command, ps []provider.Provider, limit, pageSize int) ([]provider.Order, error) {
+	results := fanOut(cmd.Context(), ps, func(ctx context.Context, p provider.Provider) ([]provider.Order, error) {
+		var orders []provider.Order
+		_, err := provider.WalkHistory(ctx, p, limit, pageSize, func(o provider.Order) error {
+			orders = append(orders, o)
+			return nil
+		})
+		return orders, err
+	})
+	orders, err := mergeResults(cmd.ErrOrStderr(), results)
+	if err != nil {
+		return nil, err
+	}
+	sortTimeline(orders)
+	return orders, nil
+}
+
+// openProviders builds clients sequentially (token refresh may update config) so the
+// fan-out afterwards only does network I/O. Providers that fail to open are reported as warnings.
+func openProviders(st *state, only []string, warn io.Writer) ([]provider.Provider, error) {
+	want := map[string]bool{}
+	for _, n := range only {
+		if n = strings.ToLower(strings.TrimSpace(n)); n != "" {
+			want[n] = true
+		}
+	}
+
+	var out []provider.Provider
+	var errs []error
+	for _, e := range providerRegistry {
+		if len(want) > 0 {
+			if !want[e.name] {
+				continue
+			}
+			delete(want, e.name)
+		} else if !e.configured(st) {
+			continue
+		}
+		p, err := e.open(st)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", e.name, err))
+			continue
+		}
+		out = append(out, p)
+	}
+	for n := range want {
+		errs = append(errs, fmt.Errorf("unknown provider %q", n))
+	}
+	if len(out) == 0 {
+		if len(errs) > 0 {
+			return nil, errors.Join(errs...)
+		}
+		return nil, errors.New("no providers logged in (run `ordercli foodora login ...` or set DELIVEROO_BEARER_TOKEN)")
+	}
+	for _, err := range errs {
+		fmt.Fprintf(warn, "warning: %v\n", err)
+	}
+	return out, nil
+}
+
+type providerResult struct {
+	name   string
+	orders []provider.Order
+	err    error
+}
+
+func fanOut(ctx context.Context, ps []provider.Provider, fn func(context.Context, provider.Provider) ([]provider.Order, error)) []providerResult {
+	results := make([]providerResult, len(ps))
+	var wg sync.WaitGroup
+	for i, p := range ps {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			orders, err := fn(ctx, p)
+			results[i] = providerResult{name: p.Name(), orders: orders, err: err}
+		}()
+	}
+	wg.Wait()
+	return results
+}
+
+// mergeResults keeps partial results: failing providers become warnings unless all failed.
+func mergeResults(warn io.Writer, results []providerResult) ([]provider.Order, error) {
+	out := []provider.Order{}
+	var errs []error
+	for _, r := range results {
+		if r.err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", r.name, r.err))
+		}
+		out = append(out, r.orders...)
+	}
+	if len(errs) == len(results) && len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	for _, err := range errs {
+		fmt.Fprintf(warn, "warning: %v\n", err)
+	}
+	return out, nil
+}
+
+func sortTimeline(orders []provider.Order) {
+	sort.SliceStable(orders, func(i, j int) bool {
+		return orders[i].Time.After(orders[j].Time)
+	})
+}
+
+func writeJSON(out io.Writer, v any) error {
+	enc := json.NewEncoder(out)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
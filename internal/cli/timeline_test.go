@@ -0,0 +1,112 @@
+package cli
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steipete/ordercli/internal/config"
+)
+
+func writeLoggedInFoodoraConfig(t *testing.T, cfgPath, baseURL string) {
+	t.Helper()
+	cfg := config.New()
+	f := cfg.Foodora()
+	f.BaseURL = baseURL
+	f.AccessToken = "access"
+	f.RefreshToken = "refresh"
+	f.ExpiresAt = time.Now().Add(time.Hour)
+	if err := config.Save(cfgPath, cfg); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+}
+
+func TestTimelineHistory_MergesProviders(t *testing.T) {
+	cfgPath := filepath.Join(t.TempDir(), "config.json")
+
+	fd := newFoodoraTestServer(t)
+	defer fd.Close()
+	writeLoggedInFoodoraConfig(t, cfgPath, fd.URL+"/")
+
+	dr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"orders":[{"id":"d1","status":"delivered","submitted_at":"2025-12-21T00:00:00Z","restaurant":{"name":"R"}}]}`))
+	}))
+	defer dr.Close()
+	if _, _, err := runCLI(cfgPath, []string{"deliveroo", "config", "set", "--base-url", dr.URL}, ""); err != nil {
+		t.Fatalf("config set: %v", err)
+	}
+	setEnv(t, "DELIVEROO_BEARER_TOKEN", "tok")
+
+	out, errOut, err := runCLI(cfgPath, []string{"history", "--limit", "5"}, "")
+	if err != nil {
+		t.Fatalf("history: %v err=%s", err, errOut)
+	}
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	if len(lines) != 2 || !strings.HasPrefix(lines[0], "deliveroo\td1\t") || !strings.HasPrefix(lines[1], "foodora\tHIST-1\t") {
+		t.Fatalf("unexpected out=%q", out)
+	}
+}
+
+func TestTimelineOrders_PartialFailure(t *testing.T) {
+	cfgPath := filepath.Join(t.TempDir(), "config.json")
+
+	fd := newFoodoraTestServer(t)
+	defer fd.Close()
+	writeLoggedInFoodoraConfig(t, cfgPath, fd.URL+"/")
+
+	dr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer dr.Close()
+	if _, _, err := runCLI(cfgPath, []string{"deliveroo", "config", "set", "--base-url", dr.URL}, ""); err != nil {
+		t.Fatalf("config set: %v", err)
+	}
+	setEnv(t, "DELIVEROO_BEARER_TOKEN", "tok")
+
+	out, errOut, err := runCLI(cfgPath, []string{"--http-retries", "0", "orders"}, "")
+	if err != nil {
+		t.Fatalf("orders: %v", err)
+	}
+	if !strings.Contains(out, "foodora\tOC-1\tVendor\tCooking") || !strings.Contains(errOut, "warning: deliveroo:") {
+		t.Fatalf("unexpected out=%q err=%q", out, errOut)
+	}
+}
+
+func TestTimelineHistory_PartialFailure(t *testing.T) {
+	cfgPath := filepath.Join(t.TempDir(), "config.json")
+
+	fd := newFoodoraTestServer(t)
+	defer fd.Close()
+	writeLoggedInFoodoraConfig(t, cfgPath, fd.URL+"/")
+
+	dr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer dr.Close()
+	if _, _, err := runCLI(cfgPath, []string{"deliveroo", "config", "set", "--base-url", dr.URL}, ""); err != nil {
+		t.Fatalf("config set: %v", err)
+	}
+	setEnv(t, "DELIVEROO_BEARER_TOKEN", "tok")
+
+	out, errOut, err := runCLI(cfgPath, []string{"--http-retries", "0", "history", "--limit", "5"}, "")
+	if err != nil {
+		t.Fatalf("history: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	if len(lines) != 1 || !strings.HasPrefix(lines[0], "foodora\tHIST-1\t") || !strings.Contains(errOut, "warning: deliveroo:") {
+		t.Fatalf("unexpected out=%q err=%q", out, errOut)
+	}
+}
+
+func TestTimelineHistory_NoProviders(t *testing.T) {
+	cfgPath := filepath.Join(t.TempDir(), "config.json")
+	setEnv(t, "DELIVEROO_BEARER_TOKEN", "")
+	_, _, err := runCLI(cfgPath, []string{"history"}, "")
+	if err == nil || !strings.Contains(err.Error(), "no providers") {
+		t.Fatalf("unexpected err: %v", err)
+	}
+}
@@ -0,0 +1,220 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steipete/ordercli/internal/provider"
+	"github.com/steipete/ordercli/internal/watch"
+)
+
+type activeOrdersOptions struct {
+	Watch bool
+	// Interval <= 0 uses the provider-suggested poll interval (default 30s).
+	Interval time.Duration
+	// ICSPath, when set, is rewritten with a calendar of the orders seen while polling.
+	ICSPath string
+	// NotifyCmd is run through the shell for every status change.
+	NotifyCmd string
+	// UntilDelivered stops watching once no watched order is pending; on by default unless
+	// streaming with SSEAddr.
+	UntilDelivered bool
+	EventsJSON     bool
+
+	WebhookURL    string
+	WebhookSecret string
+	// WebhookDeadLetter defaults to webhook-dead-letter.jsonl in the profile's data directory.
+	WebhookDeadLetter string
+
+	// SSEAddr, when set, serves the polled orders and status changes as Server-Sent Events
+	// (implies Watch). Failed polls are reported and retried instead of ending the command.
+	SSEAddr string
+}
+
+func bindWatchFlags(cmd *cobra.Command, opts *activeOrdersOptions) {
+	cmd.Flags().StringVar(&opts.ICSPath, "ics", "", "rewrite this iCalendar file after every poll")
+	cmd.Flags().StringVar(&opts.NotifyCmd, "notify-cmd", "", "shell command run per status change (event in ORDERCLI_* env vars and JSON on stdin)")
+	cmd.Flags().BoolVar(&opts.UntilDelivered, "until-delivered", true, "exit once every watched order was delivered, cancelled or is gone; waits for an order if none is active yet (off by default with --sse)")
+	cmd.Flags().BoolVar(&opts.EventsJSON, "events-json", false, "print status changes as JSON Lines")
+	cmd.Flags().StringVar(&opts.WebhookURL, "webhook", "", "POST status changes as JSON to this URL")
+	cmd.Flags().StringVar(&opts.WebhookSecret, "webhook-secret", "", "HMAC-SHA256 signing secret (env: ORDERCLI_WEBHOOK_SECRET)")
+	cmd.Flags().StringVar(&opts.WebhookDeadLetter, "webhook-dead-letter", "", "append undeliverable events here (default: the profile's data directory)")
+	cmd.Flags().StringVar(&opts.SSEAddr, "sse", "", "stream orders and status changes as Server-Sent Events on this address (e.g. :8090; implies watching)")
+}
+
+// sinks builds the configured sinks. The webhook is delivered from a background queue so a
+// dead endpoint doesn't stall polling; the returned func waits for it to drain.
+func (o activeOrdersOptions) sinks(cmd *cobra.Command, st *state) (watch.Multi, func(), error) {
+	sinks := watch.Multi{watch.WriterSink{W: cmd.OutOrStdout(), JSON: o.EventsJSON}}
+	if o.NotifyCmd != "" {
+		sinks = append(sinks, watch.CommandSink{Command: o.NotifyCmd})
+	}
+	if o.WebhookURL != "" {
+		if u, err := url.Parse(o.WebhookURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return nil, nil, fmt.Errorf("invalid --webhook URL %q (want http(s)://host/...)", o.WebhookURL)
+		}
+		deadLetter := o.WebhookDeadLetter
+		if deadLetter == "" {
+			deadLetter = filepath.Join(st.dataDir(), "webhook-dead-letter.jsonl")
+		}
+		secret := o.WebhookSecret
+		if secret == "" {
+			secret = os.Getenv("ORDERCLI_WEBHOOK_SECRET")
+		}
+		q := watch.NewQueue(cmd.Context(), watch.WebhookSink{
+			URL:        o.WebhookURL,
+			Secret:     secret,
+			DeadLetter: deadLetter,
+		}, webhookQueueSize)
+		sinks = append(sinks, q)
+		return sinks, func() {
+			ctx, cancel := context.WithTimeout(context.Background(), webhookDrainTimeout)
+			defer cancel()
+			if err := q.Close(ctx); err != nil {
+				fmt.Fprintf(cmd.ErrOrStderr(), "warning: %v\n", err)
+			}
+		}, nil
+	}
+	return sinks, func() {}, nil
+}
+
+// Events beyond webhookQueueSize, and on exit deliveries still pending after
+// webhookDrainTimeout, go to the dead-letter file.
+const (
+	webhookQueueSize    = 100
+	webhookDrainTimeout = 15 * time.Second
+)
+
+// runActiveOrders prints active orders once. With Watch it keeps polling and, after the
+// initial list, only reports status changes to the configured sinks.
+func runActiveOrders(cmd *cobra.Command, st *state, p provider.Provider, opts activeOrdersOptions) error {
+	ctx := cmd.Context()
+	if opts.SSEAddr != "" && !cmd.Flags().Changed("until-delivered") {
+		opts.UntilDelivered = false // a stream keeps going between orders
+	}
+	var cal *watchCalendar
+	if opts.ICSPath != "" {
+		cal = newWatchCalendar()
+	}
+	sinks, closeSinks, err := opts.sinks(cmd, st)
+	if err != nil {
+		return err
+	}
+	defer closeSinks()
+	var sse *watch.Broadcaster
+	if opts.SSEAddr != "" {
+		sse = watch.NewBroadcaster()
+		stop, err := serveSSE(cmd, opts.SSEAddr, sse)
+		if err != nil {
+			return err
+		}
+		defer stop()
+		sinks = append(sinks, sse)
+		opts.Watch = true
+	}
+	tracker := watch.NewTracker()
+	sawActive := false // an empty first poll waits for an order instead of exiting
+
+	for polls := 0; ; polls++ {
+		page, err := p.ActiveOrders(ctx)
+		if err != nil {
+			if sse == nil {
+				return err
+			}
+			fmt.Fprintf(cmd.ErrOrStderr(), "warning: %v\n", err)
+			sse.Fail(err, time.Now())
+			if err := sleepContext(ctx, pollSleep(opts.Interval, 0)); err != nil {
+				return err
+			}
+			continue
+		}
+		now := time.Now()
+		events := tracker.Update(page.Orders, now)
+		if polls == 0 {
+			printActiveOrders(cmd, page.Orders)
+		}
+		for _, o := range page.Orders {
+			sawActive = sawActive || o.Active
+		}
+		for _, ev := range events {
+			ev = withEventETA(ctx, p, ev)
+			if err := sinks.Send(ctx, ev); err != nil {
+				fmt.Fprintf(cmd.ErrOrStderr(), "warning: %v\n", err)
+			}
+		}
+		if sse != nil {
+			if err := sse.Snapshot(page.Orders, page.PollInterval, now); err != nil {
+				return err
+			}
+		}
+		if cal != nil {
+			cal.update(withETAs(ctx, p, page.Orders), now)
+			if err := writeOutput(cmd.OutOrStdout(), opts.ICSPath, cal.write); err != nil {
+				return err
+			}
+		}
+
+		if !opts.Watch {
+			return nil
+		}
+		if opts.UntilDelivered && sawActive && tracker.Pending() == 0 {
+			return nil
+		}
+		if err := sleepContext(ctx, pollSleep(opts.Interval, page.PollInterval)); err != nil {
+			return err
+		}
+	}
+}
+
+// pollSleep prefers an explicit interval, then the provider's suggestion, then 30s.
+func pollSleep(interval, suggested time.Duration) time.Duration {
+	switch {
+	case interval > 0:
+		return interval
+	case suggested > 0:
+		return suggested
+	default:
+		return 30 * time.Second
+	}
+}
+
+// serveSSE serves b at / and /events until the returned stop function is called.
+func serveSSE(cmd *cobra.Command, addr string, b *watch.Broadcaster) (stop func(), err error) {
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+	mux := http.NewServeMux()
+	mux.Handle("GET /{$}", b)
+	mux.Handle("GET /events", b)
+	srv := &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+	go func() { _ = srv.Serve(ln) }()
+	fmt.Fprintf(cmd.ErrOrStderr(), "streaming events on http://%s/events\n", ln.Addr())
+
+	return func() {
+		b.Close()
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = srv.Shutdown(ctx)
+	}, nil
+}
+
+// withEventETA looks up a missing ETA for orders that are still on their way.
+func withEventETA(ctx context.Context, p provider.Provider, ev watch.Event) watch.Event {
+	if ev.Kind.Final() || !ev.ETA.IsZero() {
+		return ev
+	}
+	if ep, ok := p.(provider.ETAProvider); ok {
+		if eta, err := ep.OrderETA(ctx, ev.OrderCode); err == nil {
+			ev.ETA = eta
+		}
+	}
+	return ev
+}
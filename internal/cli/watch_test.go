@@ -0,0 +1,178 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/steipete/ordercli/internal/watch"
+)
+
+func TestDeliverooOrders_WatchEventsUntilDelivered(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("notify command uses sh")
+	}
+	dir := t.TempDir()
+	cfgPath := filepath.Join(dir, "config.json")
+
+	responses := []string{
+		`{"orders":[{"id":"o1","status":"accepted","restaurant":{"name":"R"}}]}`,
+		`{"orders":[{"id":"o1","status":"accepted","restaurant":{"name":"R"}}]}`,
+		`{"orders":[{"id":"o1","status":"preparing","restaurant":{"name":"R"}}]}`,
+		`{"orders":[{"id":"o1","status":"cancelled","restaurant":{"name":"R"}}]}`,
+	}
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		i := int(calls.Add(1)) - 1
+		if i >= len(responses) {
+			t.Errorf("polled after all orders were delivered")
+			i = len(responses) - 1
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(responses[i]))
+	}))
+	defer srv.Close()
+
+	if _, _, err := runCLI(cfgPath, []string{"deliveroo", "config", "set", "--market", "uk", "--base-url", srv.URL}, ""); err != nil {
+		t.Fatalf("config set: %v", err)
+	}
+	setEnv(t, "DELIVEROO_BEARER_TOKEN", "tok")
+
+	notified := filepath.Join(dir, "notified.txt")
+	out, errOut, err := runCLI(cfgPath, []string{
+		"deliveroo", "orders", "--interval", "1ms",
+		"--notify-cmd", `echo "$ORDERCLI_EVENT $ORDERCLI_ORDER $ORDERCLI_MESSAGE" >> "` + notified + `"`,
+	}, "")
+	if err != nil {
+		t.Fatalf("orders: %v stderr=%s", err, errOut)
+	}
+
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	if len(lines) != 3 || lines[0] != "o1\tR\taccepted" ||
+		!strings.HasSuffix(lines[1], "\tstatus\tdeliveroo\to1\tR\taccepted -> preparing") ||
+		!strings.HasSuffix(lines[2], "\tcancelled\tdeliveroo\to1\tR\tpreparing -> cancelled") {
+		t.Fatalf("unexpected out:\n%s", out)
+	}
+
+	b, err := os.ReadFile(notified)
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	if string(b) != "status o1 accepted -> preparing\ncancelled o1 preparing -> cancelled\n" {
+		t.Fatalf("unexpected notifications: %q", b)
+	}
+}
+
+func TestDeliverooOrders_Webhook(t *testing.T) {
+	dir := t.TempDir()
+	cfgPath := filepath.Join(dir, "config.json")
+
+	var polls atomic.Int32
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if polls.Add(1) == 1 {
+			_, _ = w.Write([]byte(`{"orders":[{"id":"o1","status":"preparing","restaurant":{"name":"R"}}]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"orders":[]}`))
+	}))
+	defer api.Close()
+
+	var got atomic.Value
+	hook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if !watch.VerifySignature("k", body, r.Header.Get(watch.SignatureHeader)) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		got.Store(string(body))
+	}))
+	defer hook.Close()
+
+	if _, _, err := runCLI(cfgPath, []string{"deliveroo", "config", "set", "--market", "uk", "--base-url", api.URL}, ""); err != nil {
+		t.Fatalf("config set: %v", err)
+	}
+	setEnv(t, "DELIVEROO_BEARER_TOKEN", "tok")
+	setEnv(t, "ORDERCLI_WEBHOOK_SECRET", "k")
+
+	if _, errOut, err := runCLI(cfgPath, []string{"deliveroo", "orders", "--interval", "1ms", "--until-delivered", "--webhook", hook.URL}, ""); err != nil {
+		t.Fatalf("orders: %v stderr=%s", err, errOut)
+	}
+	body, _ := got.Load().(string)
+	// The order just dropped out of the list: reported as gone, not as delivered.
+	if !strings.Contains(body, `"kind":"gone"`) || !strings.Contains(body, `"order_code":"o1"`) || !strings.Contains(body, `"old_status":"preparing"`) {
+		t.Fatalf("unexpected webhook body: %q", body)
+	}
+
+	if _, _, err := runCLI(cfgPath, []string{"deliveroo", "orders", "--webhook", "ftp://x"}, ""); err == nil || !strings.Contains(err.Error(), "invalid --webhook") {
+		t.Fatalf("expected invalid URL error, got %v", err)
+	}
+}
+
+func TestFoodoraOrders_WatchExitsOnceDelivered(t *testing.T) {
+	cfgPath := filepath.Join(t.TempDir(), "config.json")
+
+	var polls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/tracking/active-orders" {
+			http.NotFound(w, r)
+			return
+		}
+		delivered := polls.Add(1) > 1
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"status":200,"data":{"poll_in_sec":1,"active_orders":[{"code":"OC-1","is_delivered":%t,"vendor":{"name":"Vendor"},"status_messages":{"subtitle":"Cooking"}}]}}`, delivered)
+	}))
+	defer srv.Close()
+	writeLoggedInFoodoraConfig(t, cfgPath, srv.URL+"/")
+
+	// No --until-delivered: exiting on delivery is the default, as for deliveroo.
+	out, errOut, err := runCLI(cfgPath, []string{"foodora", "orders", "--watch"}, "")
+	if err != nil {
+		t.Fatalf("orders: %v stderr=%s", err, errOut)
+	}
+	if polls.Load() != 2 || !strings.Contains(out, "\tdelivered\tfoodora\tOC-1\tVendor\t") {
+		t.Fatalf("polls=%d out:\n%s", polls.Load(), out)
+	}
+}
+
+func TestDeliverooOrders_WatchWaitsForFirstOrder(t *testing.T) {
+	cfgPath := filepath.Join(t.TempDir(), "config.json")
+
+	responses := []string{
+		`{"orders":[]}`,
+		`{"orders":[{"id":"o1","status":"preparing","restaurant":{"name":"R"}}]}`,
+		`{"orders":[{"id":"o1","status":"delivered","restaurant":{"name":"R"}}]}`,
+	}
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		i := int(calls.Add(1)) - 1
+		if i >= len(responses) {
+			t.Errorf("polled after the order was delivered")
+			i = len(responses) - 1
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(responses[i]))
+	}))
+	defer srv.Close()
+
+	if _, _, err := runCLI(cfgPath, []string{"deliveroo", "config", "set", "--market", "uk", "--base-url", srv.URL}, ""); err != nil {
+		t.Fatalf("config set: %v", err)
+	}
+	setEnv(t, "DELIVEROO_BEARER_TOKEN", "tok")
+
+	// Nothing active on the first poll: keep watching instead of exiting right away.
+	out, errOut, err := runCLI(cfgPath, []string{"deliveroo", "orders", "--interval", "1ms"}, "")
+	if err != nil {
+		t.Fatalf("orders: %v stderr=%s", err, errOut)
+	}
+	if calls.Load() != 3 || !strings.Contains(out, "\tnew\tdeliveroo\to1\tR\t") || !strings.Contains(out, "\tdelivered\tdeliveroo\to1\tR\t") {
+		t.Fatalf("calls=%d out:\n%s", calls.Load(), out)
+	}
+}
@@ -11,10 +11,20 @@ import (
 )
 
 type Config struct {
-	Version   int       `json:"version"`
+	Version int `json:"version"`
+	// Providers is the default profile.
 	Providers Providers `json:"providers,omitempty"`
+	// Profiles are additional named accounts, each with its own provider settings.
+	Profiles map[string]*Providers `json:"profiles,omitempty"`
+	// CurrentProfile is used when no profile is selected explicitly (empty: default).
+	CurrentProfile string `json:"current_profile,omitempty"`
+	// Secrets moves tokens, client secrets and cookies out of this file (nil: stored here).
+	Secrets *SecretsConfig `json:"secrets,omitempty"`
 }
 
+// DefaultProfile names the top-level Providers.
+const DefaultProfile = "default"
+
 type Providers struct {
 	Foodora   *FoodoraConfig   `json:"foodora,omitempty"`
 	Deliveroo *DeliverooConfig `json:"deliveroo,omitempty"`
@@ -38,11 +48,18 @@ type FoodoraConfig struct {
 	PendingMfaChannel   string    `json:"pending_mfa_channel,omitempty"`
 	PendingMfaEmail     string    `json:"pending_mfa_email,omitempty"`
 	PendingMfaCreatedAt time.Time `json:"pending_mfa_created_at,omitempty"`
+
+	// MCPAllowReorder exposes the reorder_confirm tool (adds items to the cart) in `ordercli mcp`.
+	MCPAllowReorder bool `json:"mcp_allow_reorder,omitempty"`
 }
 
 type DeliverooConfig struct {
 	Market  string `json:"market,omitempty"`
 	BaseURL string `json:"base_url,omitempty"`
+
+	// BearerToken and Cookie are a browser session (`deliveroo login`); there is no refresh.
+	BearerToken string `json:"bearer_token,omitempty"`
+	Cookie      string `json:"cookie,omitempty"`
 }
 
 func DefaultPath() (string, error) {
@@ -103,9 +120,7 @@ func Load(path string) (Config, error) {
 	if cfg.Version == 0 {
 		cfg.Version = 1
 	}
-	if cfg.Providers.Foodora != nil && cfg.Providers.Foodora.DeviceID == "" {
-		cfg.Providers.Foodora.DeviceID = newDeviceID()
-	}
+	cfg.ensureDeviceIDs()
 	return cfg, nil
 }
 
@@ -113,9 +128,7 @@ func Save(path string, cfg Config) error {
 	if cfg.Version == 0 {
 		cfg.Version = 1
 	}
-	if cfg.Providers.Foodora != nil && cfg.Providers.Foodora.DeviceID == "" {
-		cfg.Providers.Foodora.DeviceID = newDeviceID()
-	}
+	cfg.ensureDeviceIDs()
 
 	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
 		return err
@@ -140,27 +153,47 @@ func New() Config {
 	}
 }
 
-func (c *Config) Foodora() *FoodoraConfig {
-	if c.Providers.Foodora == nil {
-		c.Providers.Foodora = &FoodoraConfig{}
+// Foodora returns the default profile's foodora settings.
+func (c *Config) Foodora() *FoodoraConfig { return c.Providers.EnsureFoodora() }
+
+// Deliveroo returns the default profile's deliveroo settings.
+func (c *Config) Deliveroo() *DeliverooConfig { return c.Providers.EnsureDeliveroo() }
+
+// EnsureFoodora returns the foodora settings, creating them (with a device id) if needed.
+func (p *Providers) EnsureFoodora() *FoodoraConfig {
+	if p.Foodora == nil {
+		p.Foodora = &FoodoraConfig{}
 	}
-	if c.Providers.Foodora.DeviceID == "" {
-		c.Providers.Foodora.DeviceID = newDeviceID()
+	if p.Foodora.DeviceID == "" {
+		p.Foodora.DeviceID = newDeviceID()
 	}
-	return c.Providers.Foodora
+	return p.Foodora
 }
 
-func (c *Config) Deliveroo() *DeliverooConfig {
-	if c.Providers.Deliveroo == nil {
-		c.Providers.Deliveroo = &DeliverooConfig{}
+func (p *Providers) EnsureDeliveroo() *DeliverooConfig {
+	if p.Deliveroo == nil {
+		p.Deliveroo = &DeliverooConfig{}
+	}
+	return p.Deliveroo
+}
+
+func (c *Config) ensureDeviceIDs() {
+	for _, name := range c.ProfileNames() {
+		p, _ := c.Profile(name)
+		if p.Foodora != nil && p.Foodora.DeviceID == "" {
+			p.Foodora.DeviceID = newDeviceID()
+		}
 	}
-	return c.Providers.Deliveroo
 }
 
 func (c FoodoraConfig) HasSession() bool {
 	return c.AccessToken != "" && c.RefreshToken != ""
 }
 
+func (c DeliverooConfig) HasSession() bool {
+	return c.BearerToken != ""
+}
+
 func (c FoodoraConfig) TokenLikelyExpired(now time.Time) bool {
 	if c.AccessToken == "" {
 		return true
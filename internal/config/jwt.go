@@ -11,6 +11,10 @@ func (c FoodoraConfig) AccessTokenExpiresAt() (time.Time, bool) {
 	return AccessTokenExpiresAt(c.AccessToken)
 }
 
+func (c DeliverooConfig) AccessTokenExpiresAt() (time.Time, bool) {
+	return AccessTokenExpiresAt(c.BearerToken)
+}
+
 func AccessTokenExpiresAt(accessToken string) (time.Time, bool) {
 	return jwtExpiry(accessToken)
 }
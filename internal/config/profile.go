@@ -0,0 +1,76 @@
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+)
+
+var profileNameRe = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]{0,63}$`)
+
+// ValidateProfileName accepts letters, digits, ".", "_" and "-" (e.g. "work-corp_android").
+func ValidateProfileName(name string) error {
+	if !profileNameRe.MatchString(name) {
+		return fmt.Errorf("invalid profile name %q (letters, digits, '.', '_' and '-')", name)
+	}
+	return nil
+}
+
+// Profile returns the provider settings of a profile; "" and DefaultProfile mean the
+// top-level Providers.
+func (c *Config) Profile(name string) (*Providers, bool) {
+	if name == "" || name == DefaultProfile {
+		return &c.Providers, true
+	}
+	p, ok := c.Profiles[name]
+	if !ok || p == nil {
+		return nil, false
+	}
+	return p, true
+}
+
+// ProfileNames lists the default profile first, then the others sorted by name.
+func (c *Config) ProfileNames() []string {
+	names := make([]string, 0, len(c.Profiles))
+	for name, p := range c.Profiles {
+		if p != nil && name != DefaultProfile {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return append([]string{DefaultProfile}, names...)
+}
+
+func (c *Config) AddProfile(name string) (*Providers, error) {
+	if err := ValidateProfileName(name); err != nil {
+		return nil, err
+	}
+	if _, ok := c.Profile(name); ok {
+		return nil, fmt.Errorf("profile %q already exists", name)
+	}
+	if c.Profiles == nil {
+		c.Profiles = map[string]*Providers{}
+	}
+	p := &Providers{}
+	c.Profiles[name] = p
+	return p, nil
+}
+
+// RemoveProfile deletes a named profile; the default profile can't be removed. Removing the
+// current profile makes the default profile current again.
+func (c *Config) RemoveProfile(name string) error {
+	if name == "" || name == DefaultProfile {
+		return fmt.Errorf("can't remove the %s profile", DefaultProfile)
+	}
+	if _, ok := c.Profile(name); !ok {
+		return fmt.Errorf("unknown profile %q", name)
+	}
+	delete(c.Profiles, name)
+	if len(c.Profiles) == 0 {
+		c.Profiles = nil
+	}
+	if c.CurrentProfile == name {
+		c.CurrentProfile = ""
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package config
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestProfiles(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+
+	cfg := New()
+	cfg.Foodora().BaseURL = "https://hu.fd-api.com/api/v5/"
+	work, err := cfg.AddProfile("work-corp_android")
+	if err != nil {
+		t.Fatalf("add: %v", err)
+	}
+	work.EnsureFoodora().AccessToken = "w"
+	if _, err := cfg.AddProfile("work-corp_android"); err == nil {
+		t.Fatalf("expected duplicate error")
+	}
+	if _, err := cfg.AddProfile(DefaultProfile); err == nil {
+		t.Fatalf("expected error for default")
+	}
+	if _, err := cfg.AddProfile("../x"); err == nil {
+		t.Fatalf("expected invalid name error")
+	}
+	if _, err := cfg.AddProfile("at"); err != nil {
+		t.Fatalf("add: %v", err)
+	}
+	cfg.CurrentProfile = "at"
+
+	if err := Save(path, cfg); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+	got, err := Load(path)
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if names := strings.Join(got.ProfileNames(), ","); names != "default,at,work-corp_android" {
+		t.Fatalf("names=%s", names)
+	}
+	p, ok := got.Profile("work-corp_android")
+	if !ok || p.Foodora == nil || p.Foodora.AccessToken != "w" || p.Foodora.DeviceID == "" {
+		t.Fatalf("work profile=%+v", p)
+	}
+	if p.Foodora.DeviceID == got.Foodora().DeviceID {
+		t.Fatalf("profiles must not share a device id")
+	}
+	if def, _ := got.Profile(""); def.Foodora.BaseURL != "https://hu.fd-api.com/api/v5/" {
+		t.Fatalf("default profile=%+v", def.Foodora)
+	}
+
+	if err := got.RemoveProfile(DefaultProfile); err == nil {
+		t.Fatalf("expected error removing default")
+	}
+	if err := got.RemoveProfile("at"); err != nil {
+		t.Fatalf("remove: %v", err)
+	}
+	if got.CurrentProfile != "" {
+		t.Fatalf("current=%q", got.CurrentProfile)
+	}
+	if err := got.RemoveProfile("at"); err == nil {
+		t.Fatalf("expected unknown profile error")
+	}
+}
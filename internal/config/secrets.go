@@ -0,0 +1,75 @@
+package config
+
+import (
+	"sort"
+	"strings"
+)
+
+// SecretsConfig selects where secrets live instead of this file.
+type SecretsConfig struct {
+	// Backend is "keyring" or "file".
+	Backend string `json:"backend"`
+	// Path is the encrypted secrets file (file backend; default secrets.enc next to the config).
+	Path string `json:"path,omitempty"`
+}
+
+// SecretRefPrefix marks a config value that is stored in the secret backend under the rest
+// of the string, e.g. "secret:default/foodora/access_token".
+const SecretRefPrefix = "secret:"
+
+// SecretRef returns the reference stored in place of the secret with this key.
+func SecretRef(key string) string { return SecretRefPrefix + key }
+
+// ParseSecretRef returns the key of a reference.
+func ParseSecretRef(v string) (key string, ok bool) {
+	return strings.CutPrefix(v, SecretRefPrefix)
+}
+
+// SecretField is a secret-bearing config value: tokens, client secrets, cookies.
+type SecretField struct {
+	// Key identifies the value in the secret backend: <profile>/<provider>/<field>.
+	Key string
+	Get func() string
+	Set func(string)
+}
+
+// SecretFields lists the secret values of every profile (including empty ones).
+func (c *Config) SecretFields() []SecretField {
+	var out []SecretField
+	for _, name := range c.ProfileNames() {
+		p, _ := c.Profile(name)
+		if f := p.Foodora; f != nil {
+			prefix := name + "/foodora/"
+			out = append(out,
+				stringField(prefix+"access_token", &f.AccessToken),
+				stringField(prefix+"refresh_token", &f.RefreshToken),
+				stringField(prefix+"client_secret", &f.ClientSecret),
+				stringField(prefix+"pending_mfa_token", &f.PendingMfaToken),
+			)
+			hosts := make([]string, 0, len(f.CookiesByHost))
+			for host := range f.CookiesByHost {
+				hosts = append(hosts, host)
+			}
+			sort.Strings(hosts)
+			for _, host := range hosts {
+				out = append(out, SecretField{
+					Key: prefix + "cookie/" + host,
+					Get: func() string { return f.CookiesByHost[host] },
+					Set: func(v string) { f.CookiesByHost[host] = v },
+				})
+			}
+		}
+		if d := p.Deliveroo; d != nil {
+			prefix := name + "/deliveroo/"
+			out = append(out,
+				stringField(prefix+"bearer_token", &d.BearerToken),
+				stringField(prefix+"cookie", &d.Cookie),
+			)
+		}
+	}
+	return out
+}
+
+func stringField(key string, p *string) SecretField {
+	return SecretField{Key: key, Get: func() string { return *p }, Set: func(v string) { *p = v }}
+}
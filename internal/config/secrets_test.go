@@ -0,0 +1,39 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSecretFields(t *testing.T) {
+	cfg := New()
+	cfg.Foodora().AccessToken = "a"
+	work, _ := cfg.AddProfile("work")
+	work.EnsureFoodora().CookiesByHost = map[string]string{"b.example": "2", "a.example": "1"}
+	cfg.Providers.Deliveroo = &DeliverooConfig{Market: "uk"}
+
+	var keys []string
+	for _, f := range cfg.SecretFields() {
+		keys = append(keys, f.Key)
+	}
+	want := "default/foodora/access_token,default/foodora/refresh_token,default/foodora/client_secret,default/foodora/pending_mfa_token," +
+		"default/deliveroo/bearer_token,default/deliveroo/cookie," +
+		"work/foodora/access_token,work/foodora/refresh_token,work/foodora/client_secret,work/foodora/pending_mfa_token," +
+		"work/foodora/cookie/a.example,work/foodora/cookie/b.example"
+	if got := strings.Join(keys, ","); got != want {
+		t.Fatalf("keys=%s", got)
+	}
+
+	fields := cfg.SecretFields()
+	fields[0].Set(SecretRef(fields[0].Key))
+	fields[11].Set("3")
+	if key, ok := ParseSecretRef(cfg.Foodora().AccessToken); !ok || key != "default/foodora/access_token" {
+		t.Fatalf("ref=%q", cfg.Foodora().AccessToken)
+	}
+	if work.Foodora.CookiesByHost["b.example"] != "3" {
+		t.Fatalf("cookie not set through field")
+	}
+	if _, ok := ParseSecretRef("plain"); ok {
+		t.Fatalf("plain value parsed as reference")
+	}
+}
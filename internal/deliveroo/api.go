@@ -105,3 +105,38 @@ func OrderHistoryURL(consumerBase string, p OrderHistoryParams) (string, error)
 	u.RawQuery = q.Encode()
 	return u.String(), nil
 }
+
+// OrderURL is the detail endpoint for one order (the id from order history).
+func OrderURL(consumerBase, id string) (string, error) {
+	id = strings.TrimSpace(id)
+	if id == "" {
+		return "", fmt.Errorf("empty order id")
+	}
+	consumerBase, err := NormalizeBaseURL(consumerBase)
+	if err != nil {
+		return "", err
+	}
+	u, err := url.Parse(consumerBase + "/order-history/v1/orders/" + url.PathEscape(id))
+	if err != nil {
+		return "", err
+	}
+	return u.String(), nil
+}
+
+// ReorderURL checks a past order's items against the restaurant's current menu (read-only).
+func ReorderURL(consumerBase, id string) (string, error) {
+	u, err := OrderURL(consumerBase, id)
+	if err != nil {
+		return "", err
+	}
+	return u + "/reorder", nil
+}
+
+// BasketURL is the consumer's current basket.
+func BasketURL(consumerBase string) (string, error) {
+	consumerBase, err := NormalizeBaseURL(consumerBase)
+	if err != nil {
+		return "", err
+	}
+	return consumerBase + "/basket/v1/basket", nil
+}
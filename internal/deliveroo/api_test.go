@@ -64,3 +64,31 @@ func TestOrderHistoryURL(t *testing.T) {
 		t.Fatalf("got %q", u)
 	}
 }
+
+func TestOrderURL(t *testing.T) {
+	t.Parallel()
+
+	if _, err := OrderURL("https://example.com/consumer", " "); err == nil {
+		t.Fatalf("expected id error")
+	}
+	u, err := OrderURL("https://example.com/consumer/", "123 45")
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if u != "https://example.com/consumer/order-history/v1/orders/123%2045" {
+		t.Fatalf("got %q", u)
+	}
+}
+
+func TestReorderAndBasketURL(t *testing.T) {
+	t.Parallel()
+
+	u, err := ReorderURL("https://example.com/consumer", "o1")
+	if err != nil || u != "https://example.com/consumer/order-history/v1/orders/o1/reorder" {
+		t.Fatalf("reorder url=%q err=%v", u, err)
+	}
+	u, err = BasketURL("https://example.com/consumer/")
+	if err != nil || u != "https://example.com/consumer/basket/v1/basket" {
+		t.Fatalf("basket url=%q err=%v", u, err)
+	}
+}
@@ -1,10 +1,11 @@
 package deliveroo
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
-	"fmt"
+	"io"
 	"net/http"
 	"strings"
 	"time"
@@ -24,6 +25,8 @@ type ClientOptions struct {
 	BearerToken string
 	Cookie      string
 	Timeout     time.Duration
+	// HTTPClient overrides the default client; Timeout is ignored then.
+	HTTPClient *http.Client
 }
 
 func NewClient(opts ClientOptions) (*Client, error) {
@@ -47,10 +50,13 @@ func NewClient(opts ClientOptions) (*Client, error) {
 		return nil, err
 	}
 
+	hc := opts.HTTPClient
+	if hc == nil {
+		hc = &http.Client{Timeout: opts.Timeout}
+	}
+
 	return &Client{
-		http: &http.Client{
-			Timeout: opts.Timeout,
-		},
+		http:        hc,
 		market:      strings.TrimSpace(opts.Market),
 		consumerURL: consumer,
 		bearerToken: strings.TrimSpace(opts.BearerToken),
@@ -63,12 +69,67 @@ func (c *Client) OrderHistory(ctx context.Context, p OrderHistoryParams) (Orders
 	if err != nil {
 		return OrdersResponse{}, err
 	}
+	var out OrdersResponse
+	if err := c.getJSON(ctx, u, &out); err != nil {
+		return OrdersResponse{}, err
+	}
+	return out, nil
+}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+// ActiveOrders lists orders in progress (order history with state=active).
+func (c *Client) ActiveOrders(ctx context.Context) ([]Order, error) {
+	resp, err := c.OrderHistory(ctx, OrderHistoryParams{State: "active"})
 	if err != nil {
-		return OrdersResponse{}, err
+		return nil, err
+	}
+	return resp.Orders, nil
+}
+
+// Order fetches one order with its items, fees and delivery address.
+func (c *Client) Order(ctx context.Context, id string) (OrderDetail, error) {
+	u, err := OrderURL(c.consumerURL, id)
+	if err != nil {
+		return OrderDetail{}, err
+	}
+	var body json.RawMessage
+	if err := c.getJSON(ctx, u, &body); err != nil {
+		return OrderDetail{}, err
+	}
+	// Accept both a bare order and an {"order": {...}} envelope.
+	var env struct {
+		Order json.RawMessage `json:"order"`
+	}
+	if err := json.Unmarshal(body, &env); err == nil && len(env.Order) > 0 && env.Order[0] == '{' {
+		body = env.Order
+	}
+	var out OrderDetail
+	if err := json.Unmarshal(body, &out); err != nil {
+		return OrderDetail{}, err
+	}
+	return out, nil
+}
+
+func (c *Client) getJSON(ctx context.Context, u string, out any) error {
+	return c.doJSON(ctx, http.MethodGet, u, nil, out)
+}
+
+func (c *Client) doJSON(ctx context.Context, method, u string, body, out any) error {
+	var rd io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		rd = bytes.NewReader(b)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, u, rd)
+	if err != nil {
+		return err
 	}
 	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
 
 	auth := c.bearerToken
 	if !strings.HasPrefix(strings.ToLower(auth), "bearer ") {
@@ -84,20 +145,13 @@ func (c *Client) OrderHistory(ctx context.Context, p OrderHistoryParams) (Orders
 
 	resp, err := c.http.Do(req)
 	if err != nil {
-		return OrdersResponse{}, err
+		return err
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden {
-		return OrdersResponse{}, fmt.Errorf("deliveroo: unauthorized (%d)", resp.StatusCode)
-	}
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return OrdersResponse{}, fmt.Errorf("deliveroo: unexpected status %d", resp.StatusCode)
-	}
-
-	var out OrdersResponse
-	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
-		return OrdersResponse{}, err
+		b, _ := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+		return newHTTPError(req, resp, b)
 	}
-	return out, nil
+	return json.NewDecoder(resp.Body).Decode(out)
 }
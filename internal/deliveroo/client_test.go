@@ -2,6 +2,7 @@ package deliveroo
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -62,3 +63,40 @@ func TestClient_OrderHistory_Unauthorized(t *testing.T) {
 		t.Fatalf("unexpected err: %v", err)
 	}
 }
+
+func TestClient_Order(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/consumer/order-history/v1/orders/o1":
+			_, _ = w.Write([]byte(`{"order":{"id":"o1","currency_code":"gbp","items":[{"name":"Burger","quantity":2,"unit_price":6}]}}`))
+		case "/consumer/order-history/v1/orders/o2":
+			_, _ = w.Write([]byte(`{"id":"o2","items":[]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	c, err := NewClient(ClientOptions{BaseURL: srv.URL, BearerToken: "tok"})
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	o, err := c.Order(context.Background(), "o1")
+	if err != nil {
+		t.Fatalf("Order: %v", err)
+	}
+	if o.ID != "o1" || len(o.Items) != 1 || o.Items[0].LineTotal() != 12 {
+		t.Fatalf("unexpected order: %#v", o)
+	}
+	o, err = c.Order(context.Background(), "o2")
+	if err != nil || o.ID != "o2" {
+		t.Fatalf("bare order: %#v err=%v", o, err)
+	}
+	if _, err := c.Order(context.Background(), "missing"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,145 @@
+package deliveroo
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/steipete/ordercli/internal/redact"
+)
+
+// ErrNotFound is returned for 404 responses (e.g. an unknown order id); HTTPError matches it
+// with errors.Is.
+var ErrNotFound = errors.New("deliveroo: not found")
+
+// ErrorKind classifies a failed response so callers can react (log in again, back off, open
+// a browser) without parsing messages.
+type ErrorKind string
+
+const (
+	KindUnauthorized ErrorKind = "unauthorized"
+	KindRateLimited  ErrorKind = "rate_limited"
+	// KindBotChallenge is an anti-bot interstitial (HTML challenge page) instead of JSON.
+	KindBotChallenge ErrorKind = "bot_challenge"
+	KindNotFound     ErrorKind = "not_found"
+	KindServer       ErrorKind = "server_error"
+	KindOther        ErrorKind = "other"
+)
+
+// HTTPError is a non-2xx response. Body holds a redacted snippet, never the raw payload.
+type HTTPError struct {
+	Method     string
+	URL        string
+	StatusCode int
+	Kind       ErrorKind
+	Body       string
+	// RetryAfter is the server's Retry-After hint (zero when absent).
+	RetryAfter time.Duration
+}
+
+const errorBodySnippet = 300
+
+func (e *HTTPError) Error() string {
+	msg := fmt.Sprintf("deliveroo: %s %s: HTTP %d (%s)", e.Method, e.URL, e.StatusCode, e.Kind)
+	if e.Body != "" {
+		msg += ": " + e.Body
+	}
+	return msg
+}
+
+func (e *HTTPError) Is(target error) bool {
+	return target == ErrNotFound && e.Kind == KindNotFound
+}
+
+// KindOf returns the kind of an HTTPError anywhere in err's chain; empty otherwise.
+func KindOf(err error) ErrorKind {
+	var he *HTTPError
+	if errors.As(err, &he) {
+		return he.Kind
+	}
+	return ""
+}
+
+func newHTTPError(req *http.Request, resp *http.Response, body []byte) *HTTPError {
+	e := &HTTPError{
+		Method:     req.Method,
+		URL:        req.URL.String(),
+		StatusCode: resp.StatusCode,
+		Kind:       classify(resp, body),
+		RetryAfter: retryAfter(resp.Header.Get("Retry-After"), time.Now()),
+	}
+	if e.Kind == KindBotChallenge {
+		// Challenge pages are large HTML documents; the kind says it all.
+		e.Body = ""
+	} else {
+		e.Body = redact.Snippet(body, errorBodySnippet)
+	}
+	return e
+}
+
+func classify(resp *http.Response, body []byte) ErrorKind {
+	if isBotChallenge(resp, body) {
+		return KindBotChallenge
+	}
+	switch code := resp.StatusCode; {
+	case code == http.StatusUnauthorized, code == http.StatusForbidden:
+		return KindUnauthorized
+	case code == http.StatusTooManyRequests:
+		return KindRateLimited
+	case code == http.StatusNotFound:
+		return KindNotFound
+	case code >= 500:
+		return KindServer
+	default:
+		return KindOther
+	}
+}
+
+var botChallengeMarkers = []string{
+	"challenge-platform",
+	"cf-chl-",
+	"just a moment...",
+	"attention required",
+	"px-captcha",
+	"captcha-delivery",
+}
+
+// isBotChallenge spots Cloudflare / PerimeterX / DataDome style interstitials: an explicit
+// mitigation header, or an HTML error page carrying one of their markers.
+func isBotChallenge(resp *http.Response, body []byte) bool {
+	if strings.EqualFold(resp.Header.Get("cf-mitigated"), "challenge") {
+		return true
+	}
+	if resp.StatusCode != http.StatusForbidden && resp.StatusCode != http.StatusTooManyRequests &&
+		resp.StatusCode != http.StatusServiceUnavailable {
+		return false
+	}
+	if !strings.Contains(strings.ToLower(resp.Header.Get("Content-Type")), "html") {
+		return false
+	}
+	s := strings.ToLower(string(body))
+	for _, m := range botChallengeMarkers {
+		if strings.Contains(s, m) {
+			return true
+		}
+	}
+	return false
+}
+
+// retryAfter parses Retry-After as seconds or an HTTP date.
+func retryAfter(v string, now time.Time) time.Duration {
+	v = strings.TrimSpace(v)
+	if v == "" {
+		return 0
+	}
+	if n, err := strconv.Atoi(v); err == nil {
+		return time.Duration(max(n, 0)) * time.Second
+	}
+	if t, err := http.ParseTime(v); err == nil && t.After(now) {
+		return t.Sub(now)
+	}
+	return 0
+}
@@ -0,0 +1,82 @@
+package deliveroo
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestClient_HTTPErrorKinds(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name   string
+		status int
+		header map[string]string
+		body   string
+		kind   ErrorKind
+	}{
+		{"unauthorized", http.StatusUnauthorized, nil, `{"error":"expired","bearer_token":"secret-tok"}`, KindUnauthorized},
+		{"forbidden", http.StatusForbidden, map[string]string{"Content-Type": "application/json"}, `{}`, KindUnauthorized},
+		{"rate limited", http.StatusTooManyRequests, map[string]string{"Retry-After": "7"}, ``, KindRateLimited},
+		{"cloudflare header", http.StatusForbidden, map[string]string{"cf-mitigated": "challenge"}, `<html></html>`, KindBotChallenge},
+		{"challenge page", http.StatusForbidden, map[string]string{"Content-Type": "text/html"}, `<title>Just a moment...</title>`, KindBotChallenge},
+		{"not found", http.StatusNotFound, nil, ``, KindNotFound},
+		{"server", http.StatusBadGateway, nil, `upstream down`, KindServer},
+		{"other", http.StatusTeapot, nil, ``, KindOther},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				for k, v := range tc.header {
+					w.Header().Set(k, v)
+				}
+				w.WriteHeader(tc.status)
+				_, _ = w.Write([]byte(tc.body))
+			}))
+			t.Cleanup(srv.Close)
+
+			c, err := NewClient(ClientOptions{BaseURL: srv.URL, BearerToken: "tok"})
+			if err != nil {
+				t.Fatalf("NewClient: %v", err)
+			}
+			_, err = c.Order(context.Background(), "o1")
+
+			var he *HTTPError
+			if !errors.As(err, &he) {
+				t.Fatalf("expected *HTTPError, got %T %v", err, err)
+			}
+			if he.Kind != tc.kind || KindOf(err) != tc.kind || he.StatusCode != tc.status || he.Method != http.MethodGet {
+				t.Fatalf("unexpected error: %#v", he)
+			}
+			if !strings.HasSuffix(he.URL, "/consumer/order-history/v1/orders/o1") {
+				t.Fatalf("url=%s", he.URL)
+			}
+			if strings.Contains(err.Error(), "secret-tok") || strings.Contains(err.Error(), "<title>") {
+				t.Fatalf("error leaks body: %v", err)
+			}
+			if errors.Is(err, ErrNotFound) != (tc.kind == KindNotFound) {
+				t.Fatalf("errors.Is(ErrNotFound) mismatch for %v", err)
+			}
+			if tc.kind == KindRateLimited && he.RetryAfter != 7*time.Second {
+				t.Fatalf("retry after=%v", he.RetryAfter)
+			}
+		})
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, 12, 20, 12, 0, 0, 0, time.UTC)
+	if got := retryAfter("Sat, 20 Dec 2025 12:00:30 GMT", now); got != 30*time.Second {
+		t.Fatalf("date: %v", got)
+	}
+	if got := retryAfter("soon", now); got != 0 {
+		t.Fatalf("garbage: %v", got)
+	}
+}
@@ -3,8 +3,14 @@ package deliveroo
 import (
 	"fmt"
 	"strings"
+	"time"
 )
 
+// SubmittedTime parses SubmittedAt; zero when absent or malformed.
+func (o Order) SubmittedTime() time.Time {
+	return parseTime(o.SubmittedAt)
+}
+
 func (o Order) Summary() string {
 	parts := make([]string, 0, 8)
 
@@ -15,7 +21,7 @@ func (o Order) Summary() string {
 		parts = append(parts, "number="+o.OrderNumber)
 	}
 	if o.Status != "" {
-		parts = append(parts, "status="+o.Status)
+		parts = append(parts, "status="+string(o.Status))
 	}
 	if o.Restaurant != nil && o.Restaurant.Name != "" {
 		parts = append(parts, "restaurant="+o.Restaurant.Name)
@@ -8,7 +8,7 @@ type OrdersResponse struct {
 type Order struct {
 	ID                  string      `json:"id"`
 	OrderNumber         string      `json:"order_number"`
-	Status              string      `json:"status"`
+	Status              Status      `json:"status"`
 	StatusTimestamp     string      `json:"status_timestamp"`
 	OrderType           string      `json:"order_type"`
 	PaymentStatus       string      `json:"payment_status"`
@@ -23,5 +23,6 @@ type Order struct {
 }
 
 type Restaurant struct {
-	Name string `json:"name"`
+	Name    string   `json:"name"`
+	Address *Address `json:"address,omitempty"`
 }
@@ -0,0 +1,178 @@
+package deliveroo
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// OrderDetail is a single order from order-history/v1/orders/{id}: the history fields plus
+// line items, price components and the delivery address.
+//
+// Decoding is lenient: a mistyped field stays zero instead of hiding the whole order. The
+// original JSON is kept, so re-encoding (`--json`) preserves fields this model doesn't know.
+type OrderDetail struct {
+	Order
+
+	Items           []Item   `json:"items,omitempty"`
+	Subtotal        *float64 `json:"subtotal,omitempty"`
+	Fees            []Fee    `json:"fees,omitempty"`
+	DeliveryFee     *float64 `json:"delivery_fee,omitempty"`
+	ServiceFee      *float64 `json:"service_fee,omitempty"`
+	RiderTip        *float64 `json:"rider_tip,omitempty"`
+	DeliveryAddress *Address `json:"delivery_address,omitempty"`
+
+	raw json.RawMessage
+}
+
+type Item struct {
+	Name       string     `json:"name"`
+	Quantity   int        `json:"quantity,omitempty"`
+	UnitPrice  float64    `json:"unit_price,omitempty"`
+	TotalPrice float64    `json:"total_price,omitempty"`
+	Modifiers  []Modifier `json:"modifiers,omitempty"`
+}
+
+// Modifier is a chosen option of an item (size, extras, removed ingredients).
+type Modifier struct {
+	ID       string  `json:"id,omitempty"`
+	Name     string  `json:"name"`
+	Quantity int     `json:"quantity,omitempty"`
+	Price    float64 `json:"price,omitempty"`
+}
+
+// Fee is a price component besides the items; credits and discounts are negative.
+type Fee struct {
+	Type   string  `json:"type,omitempty"`
+	Name   string  `json:"name"`
+	Amount float64 `json:"amount"`
+}
+
+// Address decodes from an object or from a preformatted string.
+type Address struct {
+	Formatted string `json:"formatted_address,omitempty"`
+	Line1     string `json:"line1,omitempty"`
+	Line2     string `json:"line2,omitempty"`
+	PostCode  string `json:"post_code,omitempty"`
+	City      string `json:"city,omitempty"`
+}
+
+func (d *OrderDetail) UnmarshalJSON(b []byte) error {
+	b = bytes.TrimSpace(b)
+	if string(b) == "null" {
+		return nil
+	}
+	if len(b) == 0 || b[0] != '{' {
+		return fmt.Errorf("order detail: expected object, got %.20s", b)
+	}
+	type plain OrderDetail
+	var p plain
+	// Per-field type errors leave that field zero; json.Unmarshal still fills the rest.
+	_ = json.Unmarshal(b, &p)
+	*d = OrderDetail(p)
+	d.raw = append(json.RawMessage(nil), b...)
+	return nil
+}
+
+// MarshalJSON returns the original payload when the detail was decoded from JSON.
+func (d OrderDetail) MarshalJSON() ([]byte, error) {
+	if len(d.raw) > 0 {
+		return d.raw, nil
+	}
+	type plain OrderDetail
+	return json.Marshal(plain(d))
+}
+
+// Charges lists the non-zero price components: the fees array, then delivery fee, service
+// fee and rider tip unless the array already has them.
+func (d OrderDetail) Charges() []Fee {
+	var out []Fee
+	seen := map[string]bool{}
+	for _, f := range d.Fees {
+		if f.Amount == 0 {
+			continue
+		}
+		name := f.Label()
+		seen[strings.ToLower(name)] = true
+		if t := strings.TrimSpace(f.Type); t != "" {
+			seen[strings.ToLower(strings.ReplaceAll(t, "_", " "))] = true
+		}
+		out = append(out, Fee{Type: f.Type, Name: name, Amount: f.Amount})
+	}
+	add := func(name string, v *float64) {
+		if v != nil && *v != 0 && !seen[name] {
+			out = append(out, Fee{Name: name, Amount: *v})
+		}
+	}
+	add("delivery fee", d.DeliveryFee)
+	add("service fee", d.ServiceFee)
+	add("rider tip", d.RiderTip)
+	return out
+}
+
+func (d OrderDetail) RestaurantName() string {
+	if d.Restaurant == nil {
+		return ""
+	}
+	return strings.TrimSpace(d.Restaurant.Name)
+}
+
+func (d OrderDetail) Address() string {
+	if d.DeliveryAddress == nil {
+		return ""
+	}
+	return d.DeliveryAddress.String()
+}
+
+// Label is the fee name, falling back to its type (delivery_fee -> "delivery fee").
+func (f Fee) Label() string {
+	if s := strings.TrimSpace(f.Name); s != "" {
+		return s
+	}
+	if s := strings.TrimSpace(f.Type); s != "" {
+		return strings.ReplaceAll(s, "_", " ")
+	}
+	return "fee"
+}
+
+// LineTotal prefers total_price, then unit_price times quantity.
+func (i Item) LineTotal() float64 {
+	if i.TotalPrice != 0 {
+		return i.TotalPrice
+	}
+	return i.UnitPrice * float64(max(i.Quantity, 1))
+}
+
+func (a *Address) UnmarshalJSON(b []byte) error {
+	b = bytes.TrimSpace(b)
+	if len(b) > 0 && b[0] == '"' {
+		var s string
+		if err := json.Unmarshal(b, &s); err != nil {
+			return err
+		}
+		*a = Address{Formatted: s}
+		return nil
+	}
+	type plain Address
+	var p plain
+	if err := json.Unmarshal(b, &p); err != nil {
+		return err
+	}
+	*a = Address(p)
+	return nil
+}
+
+func (a Address) String() string {
+	if s := strings.TrimSpace(a.Formatted); s != "" {
+		return s
+	}
+	var parts []string
+	city := strings.TrimSpace(strings.TrimSpace(a.PostCode) + " " + strings.TrimSpace(a.City))
+	for _, s := range []string{a.Line1, a.Line2, city} {
+		if s = strings.TrimSpace(s); s != "" {
+			parts = append(parts, s)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
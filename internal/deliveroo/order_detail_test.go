@@ -0,0 +1,55 @@
+package deliveroo
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestOrderDetail_Decode(t *testing.T) {
+	t.Parallel()
+
+	in := `{
+		"id":"o1","status":"delivered","order_number":12345,"currency_code":"gbp","unknown_field":{"x":1},
+		"restaurant":{"name":"Burger Place","address":"1 High St, London"},
+		"items":[
+			{"name":"Burger","quantity":2,"total_price":13.5,"modifiers":[{"name":"Cheese","price":0.75},{"name":"No onions"}]},
+			{"name":"Fries","unit_price":3}
+		],
+		"fees":[{"type":"delivery_fee","amount":2.49},{"name":"Credit","amount":-5},{"name":"Zero","amount":0}],
+		"delivery_fee":2.49,"service_fee":0.99,"rider_tip":1.5,
+		"delivery_address":{"line1":"2 Low Rd","line2":"Flat 3","post_code":"E1 6AN","city":"London"}
+	}`
+	var d OrderDetail
+	if err := json.Unmarshal([]byte(in), &d); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if d.ID != "o1" || d.OrderNumber != "" || d.RestaurantName() != "Burger Place" {
+		t.Fatalf("unexpected detail: %#v", d)
+	}
+	if got := d.Restaurant.Address.String(); got != "1 High St, London" {
+		t.Fatalf("restaurant address=%q", got)
+	}
+	if len(d.Items) != 2 || d.Items[0].LineTotal() != 13.5 || d.Items[1].LineTotal() != 3 || len(d.Items[0].Modifiers) != 2 {
+		t.Fatalf("unexpected items: %#v", d.Items)
+	}
+	if got := d.Address(); got != "2 Low Rd, Flat 3, E1 6AN London" {
+		t.Fatalf("address=%q", got)
+	}
+
+	var names []string
+	for _, f := range d.Charges() {
+		names = append(names, f.Name)
+	}
+	if got := strings.Join(names, ","); got != "delivery fee,Credit,service fee,rider tip" {
+		t.Fatalf("charges=%s", got)
+	}
+
+	b, err := json.Marshal(d)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	if !strings.Contains(string(b), "unknown_field") || !strings.Contains(string(b), `12345`) {
+		t.Fatalf("raw not preserved: %s", b)
+	}
+}
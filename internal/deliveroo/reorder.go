@@ -0,0 +1,118 @@
+package deliveroo
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+)
+
+// ReorderPreview is a past order's items checked against the restaurant's current menu.
+type ReorderPreview struct {
+	OrderID        string        `json:"order_id"`
+	RestaurantID   string        `json:"restaurant_id"`
+	Restaurant     *Restaurant   `json:"restaurant,omitempty"`
+	RestaurantOpen *bool         `json:"restaurant_open,omitempty"`
+	CurrencyCode   string        `json:"currency_code,omitempty"`
+	Items          []ReorderItem `json:"items"`
+}
+
+// ReorderItem is one line of the past order with its current price and availability.
+type ReorderItem struct {
+	ItemID            string     `json:"item_id"`
+	Name              string     `json:"name"`
+	Quantity          int        `json:"quantity"`
+	UnitPrice         float64    `json:"unit_price,omitempty"`
+	Modifiers         []Modifier `json:"modifiers,omitempty"`
+	Available         *bool      `json:"available,omitempty"`
+	UnavailableReason string     `json:"unavailable_reason,omitempty"`
+}
+
+// BasketRequest replaces the contents of the consumer's basket.
+type BasketRequest struct {
+	RestaurantID string       `json:"restaurant_id"`
+	Items        []BasketItem `json:"items"`
+}
+
+type BasketItem struct {
+	ItemID      string   `json:"item_id"`
+	Quantity    int      `json:"quantity"`
+	ModifierIDs []string `json:"modifier_ids,omitempty"`
+}
+
+// Basket is the consumer's basket after an update. It is not an order: checkout is a
+// separate step this client never performs.
+type Basket struct {
+	ID           string   `json:"id"`
+	RestaurantID string   `json:"restaurant_id,omitempty"`
+	Items        []Item   `json:"items"`
+	Subtotal     *float64 `json:"subtotal,omitempty"`
+	Total        *float64 `json:"total,omitempty"`
+	CurrencyCode string   `json:"currency_code,omitempty"`
+}
+
+// ReorderPreview is read-only: it doesn't touch the basket.
+func (c *Client) ReorderPreview(ctx context.Context, id string) (ReorderPreview, error) {
+	u, err := ReorderURL(c.consumerURL, id)
+	if err != nil {
+		return ReorderPreview{}, err
+	}
+	var out ReorderPreview
+	if err := c.getJSON(ctx, u, &out); err != nil {
+		return ReorderPreview{}, err
+	}
+	return out, nil
+}
+
+// ReplaceBasket swaps the basket contents for req. It never checks out.
+func (c *Client) ReplaceBasket(ctx context.Context, req BasketRequest) (Basket, error) {
+	if strings.TrimSpace(req.RestaurantID) == "" {
+		return Basket{}, errors.New("basket: missing restaurant id")
+	}
+	if len(req.Items) == 0 {
+		return Basket{}, errors.New("basket: no items")
+	}
+	u, err := BasketURL(c.consumerURL)
+	if err != nil {
+		return Basket{}, err
+	}
+	var out Basket
+	if err := c.doJSON(ctx, http.MethodPut, u, req, &out); err != nil {
+		return Basket{}, err
+	}
+	return out, nil
+}
+
+// IsAvailable treats a missing availability flag as available; the basket call has the last word.
+func (i ReorderItem) IsAvailable() bool {
+	return i.Available == nil || *i.Available
+}
+
+// LineTotal is the current price of the line: unit price plus modifier prices, times quantity.
+func (i ReorderItem) LineTotal() float64 {
+	unit := i.UnitPrice
+	for _, m := range i.Modifiers {
+		unit += m.Price
+	}
+	return unit * float64(max(i.Quantity, 1))
+}
+
+// BasketRequest rebuilds the past order from its available items; the others are returned
+// as skipped.
+func (p ReorderPreview) BasketRequest() (req BasketRequest, skipped []ReorderItem) {
+	req.RestaurantID = p.RestaurantID
+	for _, it := range p.Items {
+		if !it.IsAvailable() || strings.TrimSpace(it.ItemID) == "" {
+			skipped = append(skipped, it)
+			continue
+		}
+		bi := BasketItem{ItemID: it.ItemID, Quantity: max(it.Quantity, 1)}
+		for _, m := range it.Modifiers {
+			if id := strings.TrimSpace(m.ID); id != "" {
+				bi.ModifierIDs = append(bi.ModifierIDs, id)
+			}
+		}
+		req.Items = append(req.Items, bi)
+	}
+	return req, skipped
+}
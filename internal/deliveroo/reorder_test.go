@@ -0,0 +1,70 @@
+package deliveroo
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClient_ReorderPreviewAndBasket(t *testing.T) {
+	t.Parallel()
+
+	var got BasketRequest
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method + " " + r.URL.Path {
+		case "GET /consumer/order-history/v1/orders/o1/reorder":
+			_, _ = w.Write([]byte(`{"order_id":"o1","restaurant_id":"r1","currency_code":"gbp","items":[` +
+				`{"item_id":"i1","name":"Burger","quantity":2,"unit_price":6,"modifiers":[{"id":"m1","name":"Cheese","price":0.5}]},` +
+				`{"item_id":"i2","name":"Shake","available":false,"unavailable_reason":"sold out"},` +
+				`{"name":"Mystery"}]}`))
+		case "PUT /consumer/basket/v1/basket":
+			if r.Header.Get("Content-Type") != "application/json" {
+				t.Errorf("content-type=%q", r.Header.Get("Content-Type"))
+			}
+			if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+				t.Errorf("decode: %v", err)
+			}
+			_, _ = w.Write([]byte(`{"id":"b1","items":[{"name":"Burger","quantity":2,"total_price":13}],"total":13}`))
+		default:
+			t.Errorf("unexpected %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	c, err := NewClient(ClientOptions{BaseURL: srv.URL, BearerToken: "tok"})
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	p, err := c.ReorderPreview(context.Background(), "o1")
+	if err != nil {
+		t.Fatalf("ReorderPreview: %v", err)
+	}
+	if len(p.Items) != 3 || p.Items[0].LineTotal() != 13 || p.Items[1].IsAvailable() || !p.Items[2].IsAvailable() {
+		t.Fatalf("unexpected preview: %#v", p)
+	}
+
+	req, skipped := p.BasketRequest()
+	if req.RestaurantID != "r1" || len(req.Items) != 1 || len(skipped) != 2 {
+		t.Fatalf("req=%#v skipped=%#v", req, skipped)
+	}
+	if it := req.Items[0]; it.ItemID != "i1" || it.Quantity != 2 || len(it.ModifierIDs) != 1 || it.ModifierIDs[0] != "m1" {
+		t.Fatalf("unexpected basket item: %#v", it)
+	}
+
+	b, err := c.ReplaceBasket(context.Background(), req)
+	if err != nil {
+		t.Fatalf("ReplaceBasket: %v", err)
+	}
+	if b.ID != "b1" || got.RestaurantID != "r1" || len(got.Items) != 1 {
+		t.Fatalf("basket=%#v sent=%#v", b, got)
+	}
+
+	if _, err := c.ReplaceBasket(context.Background(), BasketRequest{RestaurantID: "r1"}); err == nil {
+		t.Fatalf("expected empty basket error")
+	}
+}
@@ -0,0 +1,78 @@
+package deliveroo
+
+import (
+	"strings"
+	"time"
+)
+
+// Status is an order's status as reported by order history. Values are matched
+// case-insensitively; unknown ones are passed through.
+type Status string
+
+const (
+	StatusPending            Status = "pending"
+	StatusPlaced             Status = "placed"
+	StatusAccepted           Status = "accepted"
+	StatusInKitchen          Status = "in_kitchen"
+	StatusReadyForCollection Status = "ready_for_collection"
+	StatusInTransit          Status = "in_transit"
+	StatusDelivered          Status = "delivered"
+	StatusCompleted          Status = "completed"
+	StatusCancelled          Status = "cancelled"
+	StatusRejected           Status = "rejected"
+	StatusFailed             Status = "failed"
+)
+
+var statusLabels = map[Status]string{
+	StatusPending:            "order placed",
+	StatusPlaced:             "order placed",
+	StatusAccepted:           "accepted",
+	StatusInKitchen:          "preparing",
+	StatusReadyForCollection: "ready for collection",
+	StatusInTransit:          "rider on the way",
+	StatusDelivered:          "delivered",
+	StatusCompleted:          "delivered",
+	StatusCancelled:          "cancelled",
+	StatusRejected:           "rejected",
+	StatusFailed:             "failed",
+}
+
+func (s Status) normalized() Status {
+	return Status(strings.ToLower(strings.TrimSpace(string(s))))
+}
+
+// Label is a short human text ("preparing", "rider on the way"); unknown statuses are
+// returned with underscores as spaces.
+func (s Status) Label() string {
+	n := s.normalized()
+	if l, ok := statusLabels[n]; ok {
+		return l
+	}
+	return strings.ReplaceAll(string(n), "_", " ")
+}
+
+// Done reports whether the order reached a final state (delivered, cancelled, ...).
+func (s Status) Done() bool {
+	switch s.normalized() {
+	case StatusDelivered, StatusCompleted, StatusCancelled, StatusRejected, StatusFailed:
+		return true
+	}
+	return false
+}
+
+// Cancelled reports whether the order ended without a delivery (cancelled, rejected, failed).
+func (s Status) Cancelled() bool {
+	switch s.normalized() {
+	case StatusCancelled, StatusRejected, StatusFailed:
+		return true
+	}
+	return false
+}
+
+func parseTime(s string) time.Time {
+	t, err := time.Parse(time.RFC3339, strings.TrimSpace(s))
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
@@ -0,0 +1,26 @@
+package deliveroo
+
+import "testing"
+
+func TestStatus(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		s     Status
+		label string
+		done  bool
+	}{
+		{"IN_KITCHEN", "preparing", false},
+		{StatusInTransit, "rider on the way", false},
+		{"delivered", "delivered", true},
+		{StatusCancelled, "cancelled", true},
+		{"awaiting_rider", "awaiting rider", false},
+	} {
+		if got := tc.s.Label(); got != tc.label {
+			t.Fatalf("%q.Label()=%q want %q", tc.s, got, tc.label)
+		}
+		if got := tc.s.Done(); got != tc.done {
+			t.Fatalf("%q.Done()=%v", tc.s, got)
+		}
+	}
+}
@@ -0,0 +1,217 @@
+package export
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/steipete/ordercli/internal/provider"
+)
+
+type Format string
+
+const (
+	FormatCSV   Format = "csv"
+	FormatJSONL Format = "jsonl"
+	FormatJSON  Format = "json"
+	// FormatLedger is plain-text accounting for ledger-cli and hledger.
+	FormatLedger    Format = "ledger"
+	FormatBeancount Format = "beancount"
+)
+
+// Items controls how order line items are exported.
+type Items string
+
+const (
+	ItemsNone   Items = "none"
+	ItemsNested Items = "nested"
+	ItemsRows   Items = "rows"
+)
+
+func ParseFormat(s string) (Format, error) {
+	switch f := Format(strings.ToLower(strings.TrimSpace(s))); f {
+	case FormatCSV, FormatJSONL, FormatJSON, FormatLedger, FormatBeancount:
+		return f, nil
+	case "ndjson":
+		return FormatJSONL, nil
+	case "hledger":
+		return FormatLedger, nil
+	default:
+		return "", fmt.Errorf("unknown format %q (csv, jsonl, json, ledger, beancount)", s)
+	}
+}
+
+func ParseItems(s string) (Items, error) {
+	switch i := Items(strings.ToLower(strings.TrimSpace(s))); i {
+	case "", ItemsNone:
+		return ItemsNone, nil
+	case ItemsNested, ItemsRows:
+		return i, nil
+	default:
+		return "", fmt.Errorf("unknown items mode %q (none, nested, rows)", s)
+	}
+}
+
+// Columns are the stable CSV headers; ItemColumns are appended in ItemsRows mode.
+var (
+	Columns     = []string{"provider", "order_code", "vendor", "status", "delivered_at", "total", "currency", "item_count"}
+	ItemColumns = []string{"item_name", "item_quantity", "item_total", "item_options"}
+)
+
+// Record is the stable export shape (JSON field names match the CSV columns).
+type Record struct {
+	Provider    string `json:"provider"`
+	OrderCode   string `json:"order_code"`
+	Vendor      string `json:"vendor"`
+	Status      string `json:"status"`
+	DeliveredAt string `json:"delivered_at"`
+	Total       string `json:"total"`
+	Currency    string `json:"currency"`
+	ItemCount   string `json:"item_count"`
+	Items       []Item `json:"items,omitempty"`
+}
+
+type Item struct {
+	Name     string   `json:"name"`
+	Quantity int      `json:"quantity"`
+	Total    string   `json:"total"`
+	Options  []string `json:"options,omitempty"`
+}
+
+func NewRecord(d provider.OrderDetail, items Items) Record {
+	r := Record{
+		Provider:    d.Provider,
+		OrderCode:   d.ID,
+		Vendor:      d.Vendor.Name,
+		Status:      d.Status,
+		DeliveredAt: formatTime(d.Time),
+		Total:       formatAmount(d.Total.Amount),
+		Currency:    d.Total.Currency,
+	}
+	if d.Items > 0 {
+		r.ItemCount = strconv.Itoa(d.Items)
+	}
+	if items != ItemsNone {
+		for _, l := range d.Lines {
+			r.Items = append(r.Items, Item{
+				Name:     l.Name,
+				Quantity: l.Quantity,
+				Total:    formatAmount(l.Total.Amount),
+				Options:  l.Options,
+			})
+		}
+	}
+	return r
+}
+
+type Options struct {
+	Items Items
+
+	// Journal formats (ledger, beancount).
+	ExpenseAccount string
+	PaymentAccount string
+	// Currency is used when an order carries none.
+	Currency string
+	// Skip lists order codes already present in the journal.
+	Skip map[string]bool
+}
+
+func Write(w io.Writer, f Format, orders []provider.OrderDetail, opts Options) error {
+	switch f {
+	case FormatLedger:
+		return writeJournal(w, false, orders, opts)
+	case FormatBeancount:
+		return writeJournal(w, true, orders, opts)
+	}
+
+	records := make([]Record, 0, len(orders))
+	for _, d := range orders {
+		records = append(records, NewRecord(d, opts.Items))
+	}
+	switch f {
+	case FormatCSV:
+		return WriteCSV(w, opts.Items, records)
+	case FormatJSONL:
+		enc := json.NewEncoder(w)
+		for _, r := range records {
+			if err := enc.Encode(r); err != nil {
+				return err
+			}
+		}
+		return nil
+	case FormatJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(records)
+	default:
+		return fmt.Errorf("unknown format %q", f)
+	}
+}
+
+// WriteCSV writes one row per order; ItemsRows explodes one row per line item, and
+// ItemsNested adds an "items" column holding the JSON array.
+func WriteCSV(w io.Writer, items Items, records []Record) error {
+	cw := csv.NewWriter(w)
+	header := append([]string(nil), Columns...)
+	switch items {
+	case ItemsRows:
+		header = append(header, ItemColumns...)
+	case ItemsNested:
+		header = append(header, "items")
+	}
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+
+	for _, r := range records {
+		base := []string{r.Provider, r.OrderCode, r.Vendor, r.Status, r.DeliveredAt, r.Total, r.Currency, r.ItemCount}
+		switch items {
+		case ItemsRows:
+			if len(r.Items) == 0 {
+				if err := cw.Write(append(base, "", "", "", "")); err != nil {
+					return err
+				}
+				continue
+			}
+			for _, it := range r.Items {
+				row := append(append([]string(nil), base...), it.Name, strconv.Itoa(it.Quantity), it.Total, strings.Join(it.Options, "; "))
+				if err := cw.Write(row); err != nil {
+					return err
+				}
+			}
+		case ItemsNested:
+			nested := ""
+			if len(r.Items) > 0 {
+				b, err := json.Marshal(r.Items)
+				if err != nil {
+					return err
+				}
+				nested = string(b)
+			}
+			if err := cw.Write(append(base, nested)); err != nil {
+				return err
+			}
+		default:
+			if err := cw.Write(base); err != nil {
+				return err
+			}
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+func formatAmount(v float64) string {
+	return strconv.FormatFloat(v, 'f', 2, 64)
+}
@@ -0,0 +1,100 @@
+package export
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steipete/ordercli/internal/provider"
+)
+
+func testOrders() []provider.OrderDetail {
+	return []provider.OrderDetail{
+		{
+			Order: provider.Order{
+				Provider: "foodora",
+				ID:       "X1",
+				Vendor:   provider.Vendor{Name: "Pizza, Inc"},
+				Status:   "delivered",
+				Time:     time.Date(2025, 12, 20, 13, 0, 0, 0, time.FixedZone("CET", 3600)),
+				Total:    provider.Money{Amount: 12.3, Currency: "EUR"},
+				Items:    3,
+			},
+			Lines: []provider.Line{
+				{Name: "Pizza", Quantity: 2, Total: provider.Money{Amount: 10}, Options: []string{"Olives", "Ham"}},
+				{Name: "Cola", Quantity: 1, Total: provider.Money{Amount: 2.3}},
+			},
+		},
+		{Order: provider.Order{Provider: "deliveroo", ID: "d1"}},
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	t.Parallel()
+	if f, err := ParseFormat("NDJSON"); err != nil || f != FormatJSONL {
+		t.Fatalf("f=%q err=%v", f, err)
+	}
+	if _, err := ParseFormat("xml"); err == nil {
+		t.Fatalf("expected error")
+	}
+	if i, err := ParseItems(""); err != nil || i != ItemsNone {
+		t.Fatalf("i=%q err=%v", i, err)
+	}
+}
+
+func TestWrite_CSV(t *testing.T) {
+	t.Parallel()
+	var buf bytes.Buffer
+	if err := Write(&buf, FormatCSV, testOrders(), Options{}); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("lines=%q", lines)
+	}
+	if lines[0] != "provider,order_code,vendor,status,delivered_at,total,currency,item_count" {
+		t.Fatalf("header=%q", lines[0])
+	}
+	if lines[1] != `foodora,X1,"Pizza, Inc",delivered,2025-12-20T12:00:00Z,12.30,EUR,3` {
+		t.Fatalf("row=%q", lines[1])
+	}
+	if lines[2] != "deliveroo,d1,,,,0.00,," {
+		t.Fatalf("row=%q", lines[2])
+	}
+}
+
+func TestWrite_CSVRows(t *testing.T) {
+	t.Parallel()
+	var buf bytes.Buffer
+	if err := Write(&buf, FormatCSV, testOrders(), Options{Items: ItemsRows}); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 4 || !strings.HasSuffix(lines[0], ",item_name,item_quantity,item_total,item_options") {
+		t.Fatalf("lines=%q", lines)
+	}
+	if !strings.HasSuffix(lines[1], ",Pizza,2,10.00,Olives; Ham") || !strings.HasSuffix(lines[2], ",Cola,1,2.30,") {
+		t.Fatalf("lines=%q", lines)
+	}
+}
+
+func TestWrite_JSONLNested(t *testing.T) {
+	t.Parallel()
+	var buf bytes.Buffer
+	if err := Write(&buf, FormatJSONL, testOrders(), Options{Items: ItemsNested}); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("lines=%q", lines)
+	}
+	var r Record
+	if err := json.Unmarshal([]byte(lines[0]), &r); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if r.OrderCode != "X1" || len(r.Items) != 2 || r.Items[0].Options[1] != "Ham" {
+		t.Fatalf("unexpected: %#v", r)
+	}
+}
@@ -0,0 +1,107 @@
+package export
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/steipete/ordercli/internal/provider"
+)
+
+const (
+	DefaultExpenseAccount = "Expenses:Food:Delivery"
+	DefaultPaymentAccount = "Assets:Checking"
+)
+
+// orderCodeMetaRE matches the order_code metadata written by both ledger and beancount output.
+var orderCodeMetaRE = regexp.MustCompile(`^\s*;?\s*order_code:\s*"?([^"\s]+)"?`)
+
+// ExistingOrderCodes scans a previously exported journal for order_code metadata.
+func ExistingOrderCodes(r io.Reader) (map[string]bool, error) {
+	out := map[string]bool{}
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		if m := orderCodeMetaRE.FindStringSubmatch(sc.Text()); m != nil {
+			out[m[1]] = true
+		}
+	}
+	return out, sc.Err()
+}
+
+// IsDelivered reports whether an order should be booked: it needs a delivery time, an amount
+// and must not be active or cancelled. The status text is not consulted; it may be localized.
+func IsDelivered(o provider.Order) bool {
+	return o.Delivered() && !o.Time.IsZero() && o.Total.Amount != 0
+}
+
+// writeJournal emits one transaction per delivered order (oldest first), skipping order codes
+// in opts.Skip.
+func writeJournal(w io.Writer, beancount bool, orders []provider.OrderDetail, opts Options) error {
+	expense := strings.TrimSpace(opts.ExpenseAccount)
+	if expense == "" {
+		expense = DefaultExpenseAccount
+	}
+	payment := strings.TrimSpace(opts.PaymentAccount)
+	if payment == "" {
+		payment = DefaultPaymentAccount
+	}
+
+	for i := len(orders) - 1; i >= 0; i-- {
+		o := orders[i].Order
+		if !IsDelivered(o) || opts.Skip[o.ID] {
+			continue
+		}
+		currency := strings.ToUpper(strings.TrimSpace(o.Total.Currency))
+		if currency == "" {
+			currency = strings.ToUpper(strings.TrimSpace(opts.Currency))
+		}
+		if beancount && currency == "" {
+			return fmt.Errorf("order %s: unknown currency (pass --currency)", o.ID)
+		}
+		amount := formatAmount(o.Total.Amount)
+		if currency != "" {
+			amount += " " + currency
+		}
+		date := o.Time.In(time.Local).Format("2006-01-02")
+		payee := journalText(o.Vendor.Name)
+		if !beancount {
+			payee = ledgerPayee(payee)
+		}
+		if payee == "" {
+			payee = o.Provider
+		}
+
+		var err error
+		if beancount {
+			_, err = fmt.Fprintf(w, "%s * %s %s\n  order_code: %s\n  provider: %s\n  %s  %s\n  %s\n\n",
+				date, beancountString(payee), beancountString(o.Provider+" order "+o.ID),
+				beancountString(o.ID), beancountString(o.Provider),
+				expense, amount, payment)
+		} else {
+			_, err = fmt.Fprintf(w, "%s * %s\n    ; order_code: %s\n    ; provider: %s\n    %s    %s\n    %s\n\n",
+				date, payee, o.ID, o.Provider, expense, amount, payment)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func journalText(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// ledgerPayee drops characters with a meaning in a transaction line: hledger splits the
+// description at "|" into payee and note, and ";" starts a comment.
+func ledgerPayee(s string) string {
+	return journalText(strings.NewReplacer("|", " ", ";", " ").Replace(s))
+}
+
+func beancountString(s string) string {
+	s = strings.ReplaceAll(journalText(s), `\`, `\\`)
+	return `"` + strings.ReplaceAll(s, `"`, `\"`) + `"`
+}
@@ -0,0 +1,81 @@
+package export
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steipete/ordercli/internal/provider"
+)
+
+func journalOrders() []provider.OrderDetail {
+	noon := time.Date(2025, 12, 20, 12, 0, 0, 0, time.Local)
+	return []provider.OrderDetail{
+		{Order: provider.Order{Provider: "foodora", ID: "NEW", Vendor: provider.Vendor{Name: `Joe's "Pizza"`}, Status: "delivered", Time: noon, Total: provider.Money{Amount: 12.3}}},
+		{Order: provider.Order{Provider: "foodora", ID: "CXL", Status: "Storniert", Cancelled: true, Time: noon, Total: provider.Money{Amount: 9}}},
+		{Order: provider.Order{Provider: "foodora", ID: "ACT", Status: "Unterwegs", Active: true, Time: noon, Total: provider.Money{Amount: 7}}},
+		{Order: provider.Order{Provider: "deliveroo", ID: "OLD", Vendor: provider.Vendor{Name: "Noodles | Bar; Soho"}, Status: "delivered", Time: noon.AddDate(0, 0, -1), Total: provider.Money{Amount: 8, Currency: "gbp"}}},
+	}
+}
+
+func TestWrite_Ledger(t *testing.T) {
+	t.Parallel()
+	var buf bytes.Buffer
+	err := Write(&buf, FormatLedger, journalOrders(), Options{Currency: "EUR", PaymentAccount: "Liabilities:Card"})
+	if err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	want := `2025-12-19 * Noodles Bar Soho
+    ; order_code: OLD
+    ; provider: deliveroo
+    Expenses:Food:Delivery    8.00 GBP
+    Liabilities:Card
+
+2025-12-20 * Joe's "Pizza"
+    ; order_code: NEW
+    ; provider: foodora
+    Expenses:Food:Delivery    12.30 EUR
+    Liabilities:Card
+
+`
+	if buf.String() != want {
+		t.Fatalf("got:\n%s", buf.String())
+	}
+}
+
+func TestWrite_BeancountSkipAndCurrency(t *testing.T) {
+	t.Parallel()
+	var buf bytes.Buffer
+	if err := Write(&buf, FormatBeancount, journalOrders(), Options{}); err == nil {
+		t.Fatalf("expected missing currency error")
+	}
+
+	buf.Reset()
+	err := Write(&buf, FormatBeancount, journalOrders(), Options{Currency: "eur", Skip: map[string]bool{"OLD": true}})
+	if err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	want := `2025-12-20 * "Joe's \"Pizza\"" "foodora order NEW"
+  order_code: "NEW"
+  provider: "foodora"
+  Expenses:Food:Delivery  12.30 EUR
+  Assets:Checking
+
+`
+	if buf.String() != want {
+		t.Fatalf("got:\n%s", buf.String())
+	}
+}
+
+func TestExistingOrderCodes(t *testing.T) {
+	t.Parallel()
+	in := "2025-12-20 * X\n    ; order_code: A1\n\n2025-12-21 * \"Y\" \"\"\n  order_code: \"B2\"\n"
+	got, err := ExistingOrderCodes(strings.NewReader(in))
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if len(got) != 2 || !got["A1"] || !got["B2"] {
+		t.Fatalf("got %#v", got)
+	}
+}
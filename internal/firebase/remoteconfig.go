@@ -58,6 +58,9 @@ func NewRemoteConfigClient(cfg APKFirebaseConfig) *RemoteConfigClient {
 	}
 }
 
+// SetHTTPClient replaces the default client (20s timeout, no retries).
+func (c *RemoteConfigClient) SetHTTPClient(hc *http.Client) { c.http = hc }
+
 type Installation struct {
 	FID       string
 	AuthToken string
@@ -41,6 +41,8 @@ type Options struct {
 	FPAPIKey          string
 	AppName           string
 	OriginalUserAgent string
+	// HTTPClient overrides the default client (20s timeout, no retries).
+	HTTPClient *http.Client
 }
 
 func New(opts Options) (*Client, error) {
@@ -60,11 +62,14 @@ func New(opts Options) (*Client, error) {
 		ua = "ordercli"
 	}
 
+	hc := opts.HTTPClient
+	if hc == nil {
+		hc = &http.Client{Timeout: 20 * time.Second}
+	}
+
 	return &Client{
-		baseURL: u,
-		http: &http.Client{
-			Timeout: 20 * time.Second,
-		},
+		baseURL:        u,
+		http:           hc,
 		deviceID:       opts.DeviceID,
 		globalEntityID: opts.GlobalEntityID,
 		targetISO:      opts.TargetCountryISO,
@@ -0,0 +1,41 @@
+package foodora
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+)
+
+// FlexibleFloat decodes amounts that sometimes come back as strings (API drift).
+type FlexibleFloat float64
+
+func (f *FlexibleFloat) UnmarshalJSON(b []byte) error {
+	if string(b) == "null" {
+		*f = 0
+		return nil
+	}
+	if len(b) > 0 && b[0] == '"' {
+		var s string
+		if err := json.Unmarshal(b, &s); err != nil {
+			return err
+		}
+		s = strings.TrimSpace(s)
+		if s == "" {
+			*f = 0
+			return nil
+		}
+		v, err := strconv.ParseFloat(strings.ReplaceAll(s, ",", "."), 64)
+		if err != nil {
+			return err
+		}
+		*f = FlexibleFloat(v)
+		return nil
+	}
+
+	var v float64
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+	*f = FlexibleFloat(v)
+	return nil
+}
@@ -76,3 +76,32 @@ func TestFlexibleString(t *testing.T) {
 		t.Fatalf("got %q", v.S)
 	}
 }
+
+func TestFlexibleFloat(t *testing.T) {
+	t.Parallel()
+
+	var v struct {
+		F FlexibleFloat `json:"f"`
+	}
+	for in, want := range map[string]FlexibleFloat{
+		`{"f":1.25}`:    1.25,
+		`{"f":"2.50"}`:  2.5,
+		`{"f":"3,75"}`:  3.75,
+		`{"f":""}`:      0,
+		`{"f":null}`:    0,
+		`{"f":" 4 "}`:   4,
+		`{"f":1e2}`:     100,
+		`{"f":"-0.10"}`: -0.1,
+	} {
+		v.F = 99
+		if err := json.Unmarshal([]byte(in), &v); err != nil {
+			t.Fatalf("%s: %v", in, err)
+		}
+		if v.F != want {
+			t.Fatalf("%s: got %v", in, v.F)
+		}
+	}
+	if err := json.Unmarshal([]byte(`{"f":"abc"}`), &v); err == nil {
+		t.Fatalf("expected error")
+	}
+}
@@ -1,6 +1,9 @@
 package foodora
 
-import "time"
+import (
+	"strings"
+	"time"
+)
 
 type AuthToken struct {
 	AccessToken  string `json:"access_token"`
@@ -63,11 +66,13 @@ type StatusTitle struct {
 	Name   string `json:"name"`
 	Active bool   `json:"active"`
 	Filled bool   `json:"is_filled"`
+	// Timestamp is only sent by tracking/orders/{orderCode} for reached steps.
+	Timestamp *FlexibleTime `json:"timestamp,omitempty"`
 }
 
 type OrderStatusResponse struct {
-	Status int            `json:"status"`
-	Data   map[string]any `json:"data"`
+	Status int           `json:"status"`
+	Data   OrderTracking `json:"data"`
 }
 
 type OrderHistoryRequest struct {
@@ -93,11 +98,36 @@ type OrderHistoryItem struct {
 	ConfirmedDeliveryTime *OrderHistoryTime   `json:"confirmed_delivery_time"`
 	Vendor                *OrderHistoryVendor `json:"vendor"`
 	TotalValue            float64             `json:"total_value"`
+
+	// Currency and Products come with the default include (order_products,order_details).
+	Currency string         `json:"currency,omitempty"`
+	Products []OrderProduct `json:"order_products,omitempty"`
+	Payment  *OrderPayment  `json:"payment,omitempty"`
+}
+
+// CurrencyCode is the order currency, falling back to the payment's.
+func (it OrderHistoryItem) CurrencyCode() string {
+	return OrderHistoryDetail{Currency: it.Currency, Payment: it.Payment}.CurrencyCode()
+}
+
+// ItemCount is the number of ordered items (a product without a quantity counts once).
+func (it OrderHistoryItem) ItemCount() int {
+	n := 0
+	for _, p := range it.Products {
+		n += max(int(p.Quantity), 1)
+	}
+	return n
 }
 
 type OrderHistoryVendor struct {
 	Code string `json:"code"`
 	Name string `json:"name"`
+
+	// Only set in order details.
+	ID        FlexibleInt    `json:"id,omitzero"`
+	Address   FlexibleString `json:"address,omitempty"`
+	Latitude  FlexibleFloat  `json:"latitude,omitzero"`
+	Longitude FlexibleFloat  `json:"longitude,omitzero"`
 }
 
 type OrderHistoryStatus struct {
@@ -106,6 +136,20 @@ type OrderHistoryStatus struct {
 	InternalStatusCode FlexibleString `json:"internal_status_code"`
 }
 
+// Cancelled reports whether the order was cancelled, rejected or failed. It looks at the
+// status codes only: Message is localized ("Storniert", "Zrušená", ...).
+func (s OrderHistoryStatus) Cancelled() bool {
+	for _, code := range []FlexibleString{s.Code, s.InternalStatusCode} {
+		c := strings.ToLower(string(code))
+		for _, bad := range []string{"cancel", "reject", "declin", "fail", "refund"} {
+			if strings.Contains(c, bad) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 type OrderHistoryTime struct {
 	Date     FlexibleTime `json:"date"`
 	Timezone string       `json:"timezone"`
@@ -123,8 +167,8 @@ type OrderHistoryRawResponse struct {
 }
 
 type OrderHistoryRawData struct {
-	TotalCount FlexibleInt      `json:"total_count"`
-	Items      []map[string]any `json:"items"`
+	TotalCount FlexibleInt          `json:"total_count"`
+	Items      []OrderHistoryDetail `json:"items"`
 }
 
 type CustomerAddressesResponse struct {
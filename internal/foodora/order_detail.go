@@ -0,0 +1,284 @@
+package foodora
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+)
+
+// OrderHistoryDetail is an orders/order_history item fetched by order code
+// (include=order_products,order_details).
+//
+// Decoding is lenient: a mistyped field stays zero instead of hiding the whole order. The
+// original JSON is kept, so re-encoding (e.g. `--json`, the local archive) preserves fields
+// this model doesn't know about.
+type OrderHistoryDetail struct {
+	OrderCode             string              `json:"order_code"`
+	CurrentStatus         *OrderHistoryStatus `json:"current_status,omitempty"`
+	OrderedAt             *OrderHistoryTime   `json:"ordered_at,omitempty"`
+	ConfirmedDeliveryTime *OrderHistoryTime   `json:"confirmed_delivery_time,omitempty"`
+	ExpeditionType        FlexibleString      `json:"expedition_type,omitempty"`
+	Vendor                *OrderHistoryVendor `json:"vendor,omitempty"`
+	Products              []OrderProduct      `json:"order_products,omitempty"`
+
+	Currency    string        `json:"currency,omitempty"`
+	TotalValue  FlexibleFloat `json:"total_value,omitzero"`
+	Subtotal    FlexibleFloat `json:"subtotal,omitzero"`
+	DeliveryFee FlexibleFloat `json:"delivery_fee,omitzero"`
+	ServiceFee  FlexibleFloat `json:"service_fee,omitzero"`
+	RiderTip    FlexibleFloat `json:"rider_tip,omitzero"`
+	Discounts   []OrderCharge `json:"discounts,omitempty"`
+	Vouchers    []OrderCharge `json:"vouchers,omitempty"`
+	Payment     *OrderPayment `json:"payment,omitempty"`
+
+	// OrderAddress is the preformatted address line; DeliveryAddress the structured one.
+	OrderAddress    FlexibleString `json:"order_address,omitempty"`
+	DeliveryAddress *OrderAddress  `json:"delivery_address,omitempty"`
+
+	raw json.RawMessage
+}
+
+type OrderProduct struct {
+	ID                  FlexibleString `json:"id,omitempty"`
+	Name                string         `json:"name,omitempty"`
+	Title               string         `json:"title,omitempty"`
+	VariationName       string         `json:"variation_name,omitempty"`
+	Quantity            FlexibleInt    `json:"quantity,omitzero"`
+	Price               FlexibleFloat  `json:"price,omitzero"`
+	TotalPrice          FlexibleFloat  `json:"total_price,omitzero"`
+	TotalValue          FlexibleFloat  `json:"total_value,omitzero"`
+	SpecialInstructions string         `json:"special_instructions,omitempty"`
+	Toppings            []OrderTopping `json:"toppings,omitempty"`
+}
+
+type OrderTopping struct {
+	ID       FlexibleString `json:"id,omitempty"`
+	Name     string         `json:"name,omitempty"`
+	Quantity FlexibleInt    `json:"quantity,omitzero"`
+	Price    FlexibleFloat  `json:"price,omitzero"`
+}
+
+// OrderCharge is a discount or voucher line; the API uses either amount or value.
+type OrderCharge struct {
+	Name   string        `json:"name,omitempty"`
+	Code   string        `json:"code,omitempty"`
+	Amount FlexibleFloat `json:"amount,omitzero"`
+	Value  FlexibleFloat `json:"value,omitzero"`
+}
+
+type OrderPayment struct {
+	Method          FlexibleString `json:"method,omitempty"`
+	PaymentTypeCode FlexibleString `json:"payment_type_code,omitempty"`
+	Name            string         `json:"name,omitempty"`
+	TotalValue      FlexibleFloat  `json:"total_value,omitzero"`
+	Currency        string         `json:"currency,omitempty"`
+}
+
+type OrderAddress struct {
+	ID               FlexibleString `json:"id,omitempty"`
+	FormattedAddress string         `json:"formatted_address,omitempty"`
+	Street           string         `json:"street,omitempty"`
+	Building         FlexibleString `json:"building,omitempty"`
+	PostCode         FlexibleString `json:"postcode,omitempty"`
+	City             string         `json:"city,omitempty"`
+	Latitude         FlexibleFloat  `json:"latitude,omitzero"`
+	Longitude        FlexibleFloat  `json:"longitude,omitzero"`
+}
+
+// OrderFee is a normalized price component; discounts and vouchers are negative.
+type OrderFee struct {
+	Name   string  `json:"name"`
+	Amount float64 `json:"amount"`
+}
+
+func (d *OrderHistoryDetail) UnmarshalJSON(b []byte) error {
+	type plain OrderHistoryDetail
+	var p plain
+	raw, err := decodeLenientObject(b, &p)
+	if err != nil {
+		return fmt.Errorf("order detail: %w", err)
+	}
+	*d = OrderHistoryDetail(p)
+	d.raw = raw
+	return nil
+}
+
+// decodeLenientObject decodes as much of a JSON object as possible: per-field errors (type
+// mismatches) leave that field zero. It returns a copy of the input (nil for null).
+func decodeLenientObject(b []byte, v any) (json.RawMessage, error) {
+	b = bytes.TrimSpace(b)
+	if string(b) == "null" {
+		return nil, nil
+	}
+	if len(b) == 0 || b[0] != '{' {
+		return nil, fmt.Errorf("expected object, got %.20s", b)
+	}
+	_ = json.Unmarshal(b, v)
+	return append(json.RawMessage(nil), b...), nil
+}
+
+// MarshalJSON returns the original payload when the detail was decoded from JSON.
+func (d OrderHistoryDetail) MarshalJSON() ([]byte, error) {
+	if len(d.raw) > 0 {
+		return d.raw, nil
+	}
+	type plain OrderHistoryDetail
+	return json.Marshal(plain(d))
+}
+
+// OrderHistoryDetailFromMap decodes an untyped item (e.g. from the local archive).
+func OrderHistoryDetailFromMap(m map[string]any) (OrderHistoryDetail, error) {
+	var d OrderHistoryDetail
+	b, err := json.Marshal(m)
+	if err != nil {
+		return d, err
+	}
+	err = json.Unmarshal(b, &d)
+	return d, err
+}
+
+// Map returns the full (lossless) payload as an untyped map.
+func (d OrderHistoryDetail) Map() (map[string]any, error) {
+	b, err := json.Marshal(d)
+	if err != nil {
+		return nil, err
+	}
+	var m map[string]any
+	err = json.Unmarshal(b, &m)
+	return m, err
+}
+
+func (d OrderHistoryDetail) Status() string {
+	s := d.CurrentStatus
+	if s == nil {
+		return ""
+	}
+	switch {
+	case s.Message != "":
+		return s.Message
+	case s.Code != "":
+		return string(s.Code)
+	default:
+		return string(s.InternalStatusCode)
+	}
+}
+
+// Total is the amount charged (total_value, falling back to payment.total_value).
+func (d OrderHistoryDetail) Total() float64 {
+	if d.TotalValue != 0 {
+		return float64(d.TotalValue)
+	}
+	if d.Payment != nil {
+		return float64(d.Payment.TotalValue)
+	}
+	return 0
+}
+
+func (d OrderHistoryDetail) CurrencyCode() string {
+	if c := strings.TrimSpace(d.Currency); c != "" {
+		return strings.ToUpper(c)
+	}
+	if d.Payment != nil {
+		return strings.ToUpper(strings.TrimSpace(d.Payment.Currency))
+	}
+	return ""
+}
+
+// Fees lists the non-zero price components besides the products.
+func (d OrderHistoryDetail) Fees() []OrderFee {
+	var out []OrderFee
+	add := func(name string, v float64) {
+		if v != 0 {
+			out = append(out, OrderFee{Name: name, Amount: v})
+		}
+	}
+	add("delivery fee", float64(d.DeliveryFee))
+	add("service fee", float64(d.ServiceFee))
+	add("rider tip", float64(d.RiderTip))
+	for _, c := range d.Discounts {
+		add(c.label("discount"), -math.Abs(c.Total()))
+	}
+	for _, c := range d.Vouchers {
+		add(c.label("voucher"), -math.Abs(c.Total()))
+	}
+	return out
+}
+
+func (d OrderHistoryDetail) PaymentMethod() string {
+	p := d.Payment
+	if p == nil {
+		return ""
+	}
+	for _, s := range []string{p.Name, string(p.Method), string(p.PaymentTypeCode)} {
+		if s = strings.TrimSpace(s); s != "" {
+			return s
+		}
+	}
+	return ""
+}
+
+func (d OrderHistoryDetail) Address() string {
+	if s := strings.TrimSpace(string(d.OrderAddress)); s != "" {
+		return s
+	}
+	if d.DeliveryAddress != nil {
+		return d.DeliveryAddress.String()
+	}
+	return ""
+}
+
+func (p OrderProduct) DisplayName() string {
+	name := strings.TrimSpace(p.Name)
+	if name == "" {
+		name = strings.TrimSpace(p.Title)
+	}
+	if v := strings.TrimSpace(p.VariationName); v != "" && name != "" {
+		name += " (" + v + ")"
+	}
+	return name
+}
+
+// LineTotal prefers total_price, then total_value, then the unit price.
+func (p OrderProduct) LineTotal() float64 {
+	switch {
+	case p.TotalPrice != 0:
+		return float64(p.TotalPrice)
+	case p.TotalValue != 0:
+		return float64(p.TotalValue)
+	default:
+		return float64(p.Price)
+	}
+}
+
+func (c OrderCharge) Total() float64 {
+	if c.Amount != 0 {
+		return float64(c.Amount)
+	}
+	return float64(c.Value)
+}
+
+func (c OrderCharge) label(kind string) string {
+	for _, s := range []string{c.Name, c.Code} {
+		if s = strings.TrimSpace(s); s != "" {
+			return kind + " " + s
+		}
+	}
+	return kind
+}
+
+func (a OrderAddress) String() string {
+	if s := strings.TrimSpace(a.FormattedAddress); s != "" {
+		return s
+	}
+	street := strings.TrimSpace(strings.TrimSpace(a.Street) + " " + strings.TrimSpace(string(a.Building)))
+	city := strings.TrimSpace(strings.TrimSpace(string(a.PostCode)) + " " + strings.TrimSpace(a.City))
+	var parts []string
+	for _, s := range []string{street, city} {
+		if s != "" {
+			parts = append(parts, s)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,56 @@
+package foodora
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestOrderHistoryDetail_LenientAndLossless(t *testing.T) {
+	t.Parallel()
+
+	raw := `{"order_code":"X","total_value":"12,50","delivery_fee":"oops","vendor":{"code":"V","name":"Vendor","id":"42"},` +
+		`"order_products":[{"title":"Soup","quantity":"3","price":2}],"payment":{"total_value":13,"payment_type_code":"paypal"},` +
+		`"discounts":[{"name":"promo","amount":-2}],"mystery":{"a":[1,2]}}`
+	var d OrderHistoryDetail
+	if err := json.Unmarshal([]byte(raw), &d); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if d.OrderCode != "X" || d.Total() != 12.5 || d.DeliveryFee != 0 || d.Vendor == nil || d.Vendor.ID != 42 {
+		t.Fatalf("unexpected: %#v", d)
+	}
+	if len(d.Products) != 1 || d.Products[0].DisplayName() != "Soup" || d.Products[0].Quantity != 3 || d.Products[0].LineTotal() != 2 {
+		t.Fatalf("products: %#v", d.Products)
+	}
+	if fees := d.Fees(); len(fees) != 1 || fees[0].Name != "discount promo" || fees[0].Amount != -2 {
+		t.Fatalf("fees: %#v", fees)
+	}
+	if d.PaymentMethod() != "paypal" {
+		t.Fatalf("payment=%q", d.PaymentMethod())
+	}
+
+	b, err := json.Marshal(d)
+	if err != nil {
+		t.Fatalf("encode: %v", err)
+	}
+	if string(b) != raw {
+		t.Fatalf("not lossless:\n%s", b)
+	}
+
+	m, err := d.Map()
+	if err != nil || m["mystery"] == nil {
+		t.Fatalf("map: %v %#v", err, m)
+	}
+	back, err := OrderHistoryDetailFromMap(m)
+	if err != nil || back.Total() != 12.5 {
+		t.Fatalf("from map: %v %#v", err, back)
+	}
+}
+
+func TestOrderHistoryDetail_RejectsNonObject(t *testing.T) {
+	t.Parallel()
+	var d OrderHistoryDetail
+	if err := json.Unmarshal([]byte(`[1]`), &d); err == nil || !strings.Contains(err.Error(), "expected object") {
+		t.Fatalf("expected error, got %v", err)
+	}
+}
@@ -0,0 +1,255 @@
+package foodora
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// OrderTracking is the tracking/orders/{orderCode} payload. Like OrderHistoryDetail it decodes
+// leniently and re-encodes the original JSON (unknown fields included).
+type OrderTracking struct {
+	Code           string          `json:"code,omitempty"`
+	OrderCode      string          `json:"order_code,omitempty"`
+	Status         FlexibleString  `json:"status,omitempty"`
+	IsDelivered    bool            `json:"is_delivered,omitempty"`
+	StatusMessages StatusMessages  `json:"status_messages"`
+	StatusHistory  []TrackingEvent `json:"status_history,omitempty"`
+
+	ExpectedDeliveryTime FlexibleTime    `json:"expected_delivery_time,omitzero"`
+	DeliveryWindow       *TrackingWindow `json:"delivery_window,omitempty"`
+
+	Vendor          *TrackingVendor `json:"vendor,omitempty"`
+	Rider           *TrackingRider  `json:"rider,omitempty"`
+	DeliveryAddress *OrderAddress   `json:"delivery_address,omitempty"`
+
+	raw json.RawMessage
+}
+
+type TrackingEvent struct {
+	Code      FlexibleString `json:"code,omitempty"`
+	Message   string         `json:"message,omitempty"`
+	Timestamp FlexibleTime   `json:"timestamp,omitzero"`
+}
+
+type TrackingWindow struct {
+	From FlexibleTime `json:"from,omitzero"`
+	To   FlexibleTime `json:"to,omitzero"`
+}
+
+type TrackingVendor struct {
+	Code      string         `json:"code,omitempty"`
+	Name      string         `json:"name,omitempty"`
+	Address   FlexibleString `json:"address,omitempty"`
+	Latitude  FlexibleFloat  `json:"latitude,omitzero"`
+	Longitude FlexibleFloat  `json:"longitude,omitzero"`
+}
+
+type TrackingRider struct {
+	Name        string       `json:"name,omitempty"`
+	VehicleType string       `json:"vehicle_type,omitempty"`
+	Location    *Coordinates `json:"location,omitempty"`
+}
+
+type Coordinates struct {
+	Latitude  FlexibleFloat `json:"latitude"`
+	Longitude FlexibleFloat `json:"longitude"`
+}
+
+// TrackingStep is one entry of the progress timeline.
+type TrackingStep struct {
+	Name   string    `json:"name"`
+	Done   bool      `json:"done"`
+	Active bool      `json:"active"`
+	Time   time.Time `json:"time,omitzero"`
+}
+
+func (t *OrderTracking) UnmarshalJSON(b []byte) error {
+	type plain OrderTracking
+	var p plain
+	raw, err := decodeLenientObject(b, &p)
+	if err != nil {
+		return fmt.Errorf("order tracking: %w", err)
+	}
+	*t = OrderTracking(p)
+	t.raw = raw
+	return nil
+}
+
+// MarshalJSON returns the original payload when the tracking data was decoded from JSON.
+func (t OrderTracking) MarshalJSON() ([]byte, error) {
+	if len(t.raw) > 0 {
+		return t.raw, nil
+	}
+	type plain OrderTracking
+	return json.Marshal(plain(t))
+}
+
+func (t OrderTracking) ID() string {
+	if t.Code != "" {
+		return t.Code
+	}
+	return t.OrderCode
+}
+
+// StatusText is the subtitle the app shows, falling back to the active step and the raw status.
+func (t OrderTracking) StatusText() string {
+	if s := strings.TrimSpace(t.StatusMessages.Subtitle); s != "" {
+		return s
+	}
+	for _, st := range t.StatusMessages.Titles {
+		if st.Active && strings.TrimSpace(st.Name) != "" {
+			return strings.TrimSpace(st.Name)
+		}
+	}
+	return strings.TrimSpace(string(t.Status))
+}
+
+// Steps returns the progress timeline: the app's status titles when present, otherwise the
+// status history (oldest first).
+func (t OrderTracking) Steps() []TrackingStep {
+	var out []TrackingStep
+	for _, st := range t.StatusMessages.Titles {
+		name := strings.TrimSpace(st.Name)
+		if name == "" {
+			continue
+		}
+		step := TrackingStep{Name: name, Done: st.Filled, Active: st.Active}
+		if st.Timestamp != nil {
+			step.Time = st.Timestamp.Time
+		}
+		out = append(out, step)
+	}
+	if len(out) > 0 {
+		return out
+	}
+
+	events := append([]TrackingEvent(nil), t.StatusHistory...)
+	sort.SliceStable(events, func(i, j int) bool { return events[i].Timestamp.Before(events[j].Timestamp.Time) })
+	for i, e := range events {
+		name := strings.TrimSpace(e.Message)
+		if name == "" {
+			name = strings.TrimSpace(string(e.Code))
+		}
+		if name == "" {
+			continue
+		}
+		last := i == len(events)-1
+		out = append(out, TrackingStep{Name: name, Done: !last || t.IsDelivered, Active: last && !t.IsDelivered, Time: e.Timestamp.Time})
+	}
+	return out
+}
+
+// trackingETAKeys are other payload fields known to carry a delivery estimate, in order of
+// preference.
+var trackingETAKeys = []string{
+	"expected_delivery_time",
+	"estimated_delivery_time",
+	"promised_delivery_time",
+	"delivery_eta",
+	"eta",
+}
+
+// ETAWindow returns the delivery estimate; from == to when the API only sends a point in time.
+// Both are zero when there is no estimate.
+func (t OrderTracking) ETAWindow() (from, to time.Time) {
+	if w := t.DeliveryWindow; w != nil && !w.From.IsZero() {
+		to = w.To.Time
+		if to.IsZero() {
+			to = w.From.Time
+		}
+		return w.From.Time, to
+	}
+	if !t.ExpectedDeliveryTime.IsZero() {
+		return t.ExpectedDeliveryTime.Time, t.ExpectedDeliveryTime.Time
+	}
+	if len(t.raw) == 0 {
+		return time.Time{}, time.Time{}
+	}
+	var m map[string]any
+	if err := json.Unmarshal(t.raw, &m); err != nil {
+		return time.Time{}, time.Time{}
+	}
+	eta := searchTime(m, trackingETAKeys)
+	return eta, eta
+}
+
+// searchTime looks breadth-first through nested objects for the first key holding a time.
+func searchTime(data map[string]any, keys []string) time.Time {
+	queue := []map[string]any{data}
+	for len(queue) > 0 {
+		m := queue[0]
+		queue = queue[1:]
+		for _, k := range keys {
+			if t := anyTime(m[k]); !t.IsZero() {
+				return t
+			}
+		}
+		names := make([]string, 0, len(m))
+		for k := range m {
+			names = append(names, k)
+		}
+		sort.Strings(names)
+		for _, k := range names {
+			switch v := m[k].(type) {
+			case map[string]any:
+				queue = append(queue, v)
+			case []any:
+				for _, e := range v {
+					if em, ok := e.(map[string]any); ok {
+						queue = append(queue, em)
+					}
+				}
+			}
+		}
+	}
+	return time.Time{}
+}
+
+func anyTime(v any) time.Time {
+	if v == nil {
+		return time.Time{}
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return time.Time{}
+	}
+	var t FlexibleTime
+	if err := json.Unmarshal(b, &t); err != nil {
+		return time.Time{}
+	}
+	return t.Time
+}
+
+func (t OrderTracking) VendorLocation() (Coordinates, bool) {
+	if t.Vendor == nil {
+		return Coordinates{}, false
+	}
+	c := Coordinates{Latitude: t.Vendor.Latitude, Longitude: t.Vendor.Longitude}
+	return c, c.Valid()
+}
+
+func (t OrderTracking) RiderLocation() (Coordinates, bool) {
+	if t.Rider == nil || t.Rider.Location == nil {
+		return Coordinates{}, false
+	}
+	return *t.Rider.Location, t.Rider.Location.Valid()
+}
+
+func (t OrderTracking) AddressLocation() (Coordinates, bool) {
+	if t.DeliveryAddress == nil {
+		return Coordinates{}, false
+	}
+	c := Coordinates{Latitude: t.DeliveryAddress.Latitude, Longitude: t.DeliveryAddress.Longitude}
+	return c, c.Valid()
+}
+
+func (c Coordinates) Valid() bool {
+	return c.Latitude != 0 || c.Longitude != 0
+}
+
+func (c Coordinates) String() string {
+	return fmt.Sprintf("%.6f,%.6f", float64(c.Latitude), float64(c.Longitude))
+}
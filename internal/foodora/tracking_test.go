@@ -0,0 +1,62 @@
+package foodora
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestOrderTracking_ETAWindow(t *testing.T) {
+	t.Parallel()
+
+	decode := func(s string) OrderTracking {
+		t.Helper()
+		var tr OrderTracking
+		if err := json.Unmarshal([]byte(s), &tr); err != nil {
+			t.Fatalf("decode %s: %v", s, err)
+		}
+		return tr
+	}
+	at := time.Date(2025, 12, 20, 18, 30, 0, 0, time.UTC)
+
+	from, to := decode(`{"delivery_window":{"from":"2025-12-20T18:30:00Z","to":"2025-12-20T18:45:00Z"}}`).ETAWindow()
+	if !from.Equal(at) || !to.Equal(at.Add(15*time.Minute)) {
+		t.Fatalf("window: %v %v", from, to)
+	}
+	from, to = decode(`{"expected_delivery_time":"2025-12-20T18:30:00Z"}`).ETAWindow()
+	if !from.Equal(at) || !to.Equal(at) {
+		t.Fatalf("point: %v %v", from, to)
+	}
+	// Untyped fallback: nested fields and epoch seconds.
+	if from, _ = decode(`{"delivery":{"estimated_delivery_time":1766255400}}`).ETAWindow(); from.Unix() != 1766255400 {
+		t.Fatalf("fallback: %v", from)
+	}
+	if from, _ = decode(`{"eta":"soon"}`).ETAWindow(); !from.IsZero() {
+		t.Fatalf("expected zero, got %v", from)
+	}
+}
+
+func TestOrderTracking_StepsFromHistory(t *testing.T) {
+	t.Parallel()
+
+	var tr OrderTracking
+	raw := `{"order_code":"X","status":"picked_up","status_history":[
+		{"code":"picked_up","timestamp":"2025-12-20T18:20:00Z"},
+		{"code":"accepted","message":"Accepted","timestamp":"2025-12-20T18:00:00Z"}],"extra":true}`
+	if err := json.Unmarshal([]byte(raw), &tr); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	steps := tr.Steps()
+	if len(steps) != 2 || steps[0].Name != "Accepted" || !steps[0].Done || steps[1].Name != "picked_up" || !steps[1].Active {
+		t.Fatalf("steps: %#v", steps)
+	}
+	if tr.ID() != "X" || tr.StatusText() != "picked_up" {
+		t.Fatalf("id=%q status=%q", tr.ID(), tr.StatusText())
+	}
+	var want bytes.Buffer
+	_ = json.Compact(&want, []byte(raw))
+	if b, _ := json.Marshal(tr); string(b) != want.String() {
+		t.Fatalf("not lossless: %s", b)
+	}
+}
@@ -0,0 +1,118 @@
+package ical
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+type Event struct {
+	UID         string
+	Start       time.Time
+	End         time.Time
+	Summary     string
+	Description string
+	Location    string
+	// Status is CONFIRMED, TENTATIVE or CANCELLED (empty omits the property).
+	Status string
+}
+
+type Calendar struct {
+	Name   string
+	Events []Event
+	// Now is used for DTSTAMP (default: time.Now()).
+	Now time.Time
+}
+
+const utcLayout = "20060102T150405Z"
+
+// Write renders an RFC 5545 calendar (CRLF line endings, 75-octet folding).
+func Write(w io.Writer, c Calendar) error {
+	now := c.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+	bw := bufio.NewWriter(w)
+	line := func(name, value string) {
+		writeFolded(bw, name+":"+value)
+	}
+
+	line("BEGIN", "VCALENDAR")
+	line("VERSION", "2.0")
+	line("PRODID", "-//ordercli//orders//EN")
+	line("CALSCALE", "GREGORIAN")
+	if c.Name != "" {
+		line("X-WR-CALNAME", escapeText(c.Name))
+	}
+	for _, e := range c.Events {
+		if e.Start.IsZero() {
+			continue
+		}
+		end := e.End
+		if end.IsZero() || !end.After(e.Start) {
+			end = e.Start.Add(15 * time.Minute)
+		}
+		line("BEGIN", "VEVENT")
+		line("UID", escapeText(e.UID))
+		line("DTSTAMP", now.UTC().Format(utcLayout))
+		line("DTSTART", e.Start.UTC().Format(utcLayout))
+		line("DTEND", end.UTC().Format(utcLayout))
+		line("SUMMARY", escapeText(e.Summary))
+		if e.Description != "" {
+			line("DESCRIPTION", escapeText(e.Description))
+		}
+		if e.Location != "" {
+			line("LOCATION", escapeText(e.Location))
+		}
+		if e.Status != "" {
+			line("STATUS", e.Status)
+		}
+		line("END", "VEVENT")
+	}
+	line("END", "VCALENDAR")
+	return bw.Flush()
+}
+
+func escapeText(s string) string {
+	r := strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`, "\r", `\n`)
+	return r.Replace(s)
+}
+
+// writeFolded splits content lines longer than 75 octets without breaking UTF-8 sequences.
+func writeFolded(w *bufio.Writer, s string) {
+	const limit = 75
+	first := true
+	for len(s) > 0 {
+		n := limit
+		if !first {
+			n = limit - 1 // continuation lines start with a space
+		}
+		if len(s) <= n {
+			if !first {
+				_ = w.WriteByte(' ')
+			}
+			_, _ = w.WriteString(s)
+			break
+		}
+		cut := n
+		for cut > 0 && !utf8.RuneStart(s[cut]) {
+			cut--
+		}
+		if !first {
+			_ = w.WriteByte(' ')
+		}
+		_, _ = w.WriteString(s[:cut])
+		_, _ = w.WriteString("\r\n")
+		s = s[cut:]
+		first = false
+	}
+	_, _ = w.WriteString("\r\n")
+}
+
+// UID builds a stable event identifier for an order.
+func UID(provider, id string) string {
+	return fmt.Sprintf("%s-%s@ordercli", provider, id)
+}
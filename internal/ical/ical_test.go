@@ -0,0 +1,66 @@
+package ical
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWrite(t *testing.T) {
+	t.Parallel()
+	start := time.Date(2025, 12, 20, 18, 30, 0, 0, time.FixedZone("CET", 3600))
+	var buf bytes.Buffer
+	err := Write(&buf, Calendar{
+		Name: "orders",
+		Now:  time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+		Events: []Event{
+			{UID: UID("foodora", "A-1"), Start: start, Summary: "Pizza, Pasta; more", Description: "line1\nline2", Status: "CONFIRMED"},
+			{UID: "skipped"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		"BEGIN:VCALENDAR\r\n",
+		"UID:foodora-A-1@ordercli\r\n",
+		"DTSTAMP:20260101T000000Z\r\n",
+		"DTSTART:20251220T173000Z\r\n",
+		"DTEND:20251220T174500Z\r\n",
+		`SUMMARY:Pizza\, Pasta\; more` + "\r\n",
+		`DESCRIPTION:line1\nline2` + "\r\n",
+		"END:VCALENDAR\r\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("missing %q in:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "skipped") || strings.Count(out, "BEGIN:VEVENT") != 1 {
+		t.Fatalf("events without start must be dropped:\n%s", out)
+	}
+}
+
+func TestWrite_FoldsLongLines(t *testing.T) {
+	t.Parallel()
+	var buf bytes.Buffer
+	summary := strings.Repeat("ü", 60)
+	if err := Write(&buf, Calendar{Events: []Event{{UID: "x", Start: time.Unix(0, 0), Summary: summary}}}); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	var unfolded strings.Builder
+	for i, l := range strings.Split(strings.TrimSuffix(buf.String(), "\r\n"), "\r\n") {
+		if len(l) > 75 {
+			t.Fatalf("line %d longer than 75 octets: %q", i, l)
+		}
+		if strings.HasPrefix(l, " ") {
+			unfolded.WriteString(l[1:])
+			continue
+		}
+		unfolded.WriteString("\n" + l)
+	}
+	if !strings.Contains(unfolded.String(), "SUMMARY:"+summary) {
+		t.Fatalf("unfold mismatch:\n%s", unfolded.String())
+	}
+}
@@ -0,0 +1,194 @@
+package mcp
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// LatestProtocolVersion is answered when the client asks for a version we don't know.
+const LatestProtocolVersion = "2025-06-18"
+
+var supportedVersions = map[string]bool{
+	"2024-11-05": true,
+	"2025-03-26": true,
+	"2025-06-18": true,
+}
+
+// JSON-RPC error codes.
+const (
+	codeParseError     = -32700
+	codeInvalidRequest = -32600
+	codeMethodNotFound = -32601
+	codeInvalidParams  = -32602
+)
+
+// Tool is a callable exposed via tools/list and tools/call. Handler results must encode to a
+// JSON object; they are returned both as structured content and as JSON text.
+type Tool struct {
+	Name        string
+	Description string
+	InputSchema map[string]any
+	Handler     func(ctx context.Context, args json.RawMessage) (any, error)
+}
+
+// Server is a minimal Model Context Protocol server (tools only) speaking newline-delimited
+// JSON-RPC 2.0, as used by the stdio transport.
+type Server struct {
+	name    string
+	version string
+	tools   []Tool
+
+	mu  sync.Mutex
+	out *json.Encoder
+}
+
+func NewServer(name, version string) *Server {
+	return &Server{name: name, version: version}
+}
+
+func (s *Server) AddTool(t Tool) {
+	s.tools = append(s.tools, t)
+}
+
+type request struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+type response struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  any             `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// Serve handles requests from r until EOF or ctx is done. Requests are handled one at a time.
+func (s *Server) Serve(ctx context.Context, r io.Reader, w io.Writer) error {
+	s.out = json.NewEncoder(w)
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 0, 64<<10), 8<<20)
+	for sc.Scan() {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		line := sc.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		var req request
+		if err := json.Unmarshal(line, &req); err != nil {
+			s.write(response{ID: json.RawMessage("null"), Error: &rpcError{Code: codeParseError, Message: err.Error()}})
+			continue
+		}
+		if len(req.ID) == 0 {
+			// Notifications (e.g. notifications/initialized) get no response.
+			continue
+		}
+		if req.JSONRPC != "2.0" || req.Method == "" {
+			s.write(response{ID: req.ID, Error: &rpcError{Code: codeInvalidRequest, Message: "invalid request"}})
+			continue
+		}
+		result, rerr := s.handle(ctx, req)
+		s.write(response{ID: req.ID, Result: result, Error: rerr})
+	}
+	return sc.Err()
+}
+
+func (s *Server) write(resp response) {
+	resp.JSONRPC = "2.0"
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_ = s.out.Encode(resp)
+}
+
+func (s *Server) handle(ctx context.Context, req request) (any, *rpcError) {
+	switch req.Method {
+	case "initialize":
+		var p struct {
+			ProtocolVersion string `json:"protocolVersion"`
+		}
+		_ = json.Unmarshal(req.Params, &p)
+		version := p.ProtocolVersion
+		if !supportedVersions[version] {
+			version = LatestProtocolVersion
+		}
+		return map[string]any{
+			"protocolVersion": version,
+			"capabilities":    map[string]any{"tools": map[string]any{}},
+			"serverInfo":      map[string]any{"name": s.name, "version": s.version},
+		}, nil
+	case "ping":
+		return map[string]any{}, nil
+	case "tools/list":
+		tools := make([]map[string]any, 0, len(s.tools))
+		for _, t := range s.tools {
+			schema := t.InputSchema
+			if schema == nil {
+				schema = map[string]any{"type": "object", "properties": map[string]any{}}
+			}
+			tools = append(tools, map[string]any{"name": t.Name, "description": t.Description, "inputSchema": schema})
+		}
+		return map[string]any{"tools": tools}, nil
+	case "tools/call":
+		var p struct {
+			Name      string          `json:"name"`
+			Arguments json.RawMessage `json:"arguments"`
+		}
+		if err := json.Unmarshal(req.Params, &p); err != nil || p.Name == "" {
+			return nil, &rpcError{Code: codeInvalidParams, Message: "tools/call needs a tool name"}
+		}
+		for _, t := range s.tools {
+			if t.Name == p.Name {
+				return callTool(ctx, t, p.Arguments), nil
+			}
+		}
+		return nil, &rpcError{Code: codeInvalidParams, Message: fmt.Sprintf("unknown tool %q", p.Name)}
+	default:
+		return nil, &rpcError{Code: codeMethodNotFound, Message: "method not found: " + req.Method}
+	}
+}
+
+// callTool reports handler failures as tool results (isError), so the model can see them.
+func callTool(ctx context.Context, t Tool, args json.RawMessage) map[string]any {
+	if len(args) == 0 || string(args) == "null" {
+		args = json.RawMessage("{}")
+	}
+	v, err := t.Handler(ctx, args)
+	if err == nil {
+		var b []byte
+		b, err = json.Marshal(v)
+		if err == nil {
+			return map[string]any{
+				"content":           []map[string]any{{"type": "text", "text": string(b)}},
+				"structuredContent": json.RawMessage(b),
+			}
+		}
+	}
+	return map[string]any{
+		"content": []map[string]any{{"type": "text", "text": err.Error()}},
+		"isError": true,
+	}
+}
+
+// DecodeArgs unmarshals tool arguments, rejecting unknown fields.
+func DecodeArgs(args json.RawMessage, v any) error {
+	dec := json.NewDecoder(bytes.NewReader(args))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("invalid arguments: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+package mcp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func serve(t *testing.T, s *Server, in string) []map[string]any {
+	t.Helper()
+	var out bytes.Buffer
+	if err := s.Serve(context.Background(), strings.NewReader(in), &out); err != nil {
+		t.Fatalf("serve: %v", err)
+	}
+	var replies []map[string]any
+	dec := json.NewDecoder(&out)
+	for dec.More() {
+		var m map[string]any
+		if err := dec.Decode(&m); err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+		replies = append(replies, m)
+	}
+	return replies
+}
+
+func TestServer(t *testing.T) {
+	t.Parallel()
+
+	s := NewServer("test", "1.0")
+	s.AddTool(Tool{
+		Name: "echo",
+		Handler: func(ctx context.Context, args json.RawMessage) (any, error) {
+			var a struct {
+				Text string `json:"text"`
+			}
+			if err := DecodeArgs(args, &a); err != nil {
+				return nil, err
+			}
+			if a.Text == "" {
+				return nil, errors.New("empty text")
+			}
+			return map[string]string{"text": a.Text}, nil
+		},
+	})
+
+	replies := serve(t, s, strings.Join([]string{
+		`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"1999-01-01"}}`,
+		`{"jsonrpc":"2.0","method":"notifications/initialized"}`,
+		`not json`,
+		`{"jsonrpc":"2.0","id":"b","method":"tools/call","params":{"name":"echo","arguments":{"text":"hi"}}}`,
+		`{"jsonrpc":"2.0","id":3,"method":"tools/call","params":{"name":"echo","arguments":{}}}`,
+		`{"jsonrpc":"2.0","id":4,"method":"tools/call","params":{"name":"echo","arguments":{"bogus":1}}}`,
+		`{"jsonrpc":"2.0","id":5,"method":"resources/list"}`,
+		`{"jsonrpc":"2.0","id":6,"method":"tools/list"}`,
+	}, "\n"))
+	if len(replies) != 7 {
+		t.Fatalf("replies=%d %v", len(replies), replies)
+	}
+
+	info := replies[0]["result"].(map[string]any)
+	if info["protocolVersion"] != LatestProtocolVersion {
+		t.Fatalf("init=%v", info)
+	}
+	if code := replies[1]["error"].(map[string]any)["code"]; code != float64(codeParseError) {
+		t.Fatalf("parse error=%v", replies[1])
+	}
+
+	call := replies[2]
+	if call["id"] != "b" {
+		t.Fatalf("id=%v", call["id"])
+	}
+	res := call["result"].(map[string]any)
+	if res["structuredContent"].(map[string]any)["text"] != "hi" || res["isError"] != nil {
+		t.Fatalf("call=%v", res)
+	}
+
+	for _, r := range replies[3:5] {
+		res := r["result"].(map[string]any)
+		if res["isError"] != true {
+			t.Fatalf("want tool error, got %v", r)
+		}
+	}
+	if code := replies[5]["error"].(map[string]any)["code"]; code != float64(codeMethodNotFound) {
+		t.Fatalf("unknown method=%v", replies[5])
+	}
+	tools := replies[6]["result"].(map[string]any)["tools"].([]any)
+	if len(tools) != 1 || tools[0].(map[string]any)["inputSchema"] == nil {
+		t.Fatalf("tools=%v", tools)
+	}
+}
@@ -0,0 +1,193 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/steipete/ordercli/internal/deliveroo"
+)
+
+const DeliverooName = "deliveroo"
+
+type DeliverooOptions struct {
+	IncludeUgc bool
+	// State filters History (e.g. "active"); the API answers a state filter with one page.
+	State string
+}
+
+type Deliveroo struct {
+	c    *deliveroo.Client
+	opts DeliverooOptions
+}
+
+func NewDeliveroo(c *deliveroo.Client, opts DeliverooOptions) *Deliveroo {
+	return &Deliveroo{c: c, opts: opts}
+}
+
+func (d *Deliveroo) Name() string { return DeliverooName }
+
+func (d *Deliveroo) History(ctx context.Context, req HistoryRequest) (HistoryPage, error) {
+	limit := req.Limit
+	if limit <= 0 {
+		limit = 10
+	}
+	offset := max(0, req.Offset)
+	resp, err := d.c.OrderHistory(ctx, deliveroo.OrderHistoryParams{
+		Offset:     offset,
+		Limit:      limit,
+		IncludeUgc: d.opts.IncludeUgc,
+		State:      d.opts.State,
+	})
+	if err != nil {
+		return HistoryPage{}, err
+	}
+	out := HistoryPage{Total: resp.Count}
+	for _, o := range resp.Orders {
+		out.Orders = append(out.Orders, DeliverooOrder(o))
+	}
+	if d.opts.State != "" {
+		out.Total = offset + len(out.Orders)
+	}
+	return out, nil
+}
+
+// ActiveOrders is best-effort: Deliveroo exposes active orders via order history with state=active.
+// Orders the list still carries in a final state (delivered, cancelled) are reported inactive.
+func (d *Deliveroo) ActiveOrders(ctx context.Context) (ActivePage, error) {
+	orders, err := d.c.ActiveOrders(ctx)
+	if err != nil {
+		return ActivePage{}, err
+	}
+	var out ActivePage
+	for _, o := range orders {
+		n := DeliverooOrder(o)
+		n.Active = !o.Status.Done()
+		out.Orders = append(out.Orders, n)
+	}
+	return out, nil
+}
+
+func (d *Deliveroo) Order(ctx context.Context, id string) (OrderDetail, error) {
+	o, err := d.c.Order(ctx, id)
+	if errors.Is(err, deliveroo.ErrNotFound) {
+		return OrderDetail{}, ErrNotFound
+	}
+	if err != nil {
+		return OrderDetail{}, err
+	}
+	return DeliverooOrderDetail(o), nil
+}
+
+// ReorderPreview is read-only: it checks the past order against the current menu and never
+// touches the basket.
+func (d *Deliveroo) ReorderPreview(ctx context.Context, id string) (OrderDetail, error) {
+	p, err := d.c.ReorderPreview(ctx, id)
+	if errors.Is(err, deliveroo.ErrNotFound) {
+		return OrderDetail{}, ErrNotFound
+	}
+	if err != nil {
+		return OrderDetail{}, err
+	}
+	return DeliverooReorderPreview(p), nil
+}
+
+func DeliverooOrder(o deliveroo.Order) Order {
+	n := Order{
+		Provider:  DeliverooName,
+		ID:        o.ID,
+		Status:    o.Status.Label(),
+		Time:      parseTime(o.DeliveredAt),
+		Submitted: o.SubmittedTime(),
+		ETA:       parseTime(o.EstimatedDeliveryAt),
+		Cancelled: o.Status.Cancelled(),
+	}
+	if n.Time.IsZero() {
+		n.Time = n.Submitted
+	}
+	if o.Restaurant != nil {
+		n.Vendor.Name = o.Restaurant.Name
+	}
+	if o.Total != nil {
+		n.Total = Money{Amount: *o.Total, Currency: strings.ToUpper(o.CurrencyCode)}
+	}
+	return n
+}
+
+func DeliverooOrderDetail(o deliveroo.OrderDetail) OrderDetail {
+	currency := strings.ToUpper(o.CurrencyCode)
+	d := OrderDetail{
+		Order:   DeliverooOrder(o.Order),
+		Address: o.Address(),
+	}
+	for _, it := range o.Items {
+		name := strings.TrimSpace(it.Name)
+		if name == "" {
+			continue
+		}
+		l := Line{
+			Name:     name,
+			Quantity: it.Quantity,
+			Total:    Money{Amount: it.LineTotal(), Currency: currency},
+		}
+		for _, m := range it.Modifiers {
+			if mn := strings.TrimSpace(m.Name); mn != "" {
+				l.Options = append(l.Options, mn)
+			}
+		}
+		d.Lines = append(d.Lines, l)
+		d.Items += max(l.Quantity, 1)
+	}
+	for _, f := range o.Charges() {
+		d.Fees = append(d.Fees, Fee{Name: f.Name, Amount: Money{Amount: f.Amount, Currency: currency}})
+	}
+	return d
+}
+
+func DeliverooReorderPreview(p deliveroo.ReorderPreview) OrderDetail {
+	currency := strings.ToUpper(p.CurrencyCode)
+	d := OrderDetail{Order: Order{Provider: DeliverooName, ID: p.OrderID}}
+	d.Vendor.ID = p.RestaurantID
+	if p.Restaurant != nil {
+		d.Vendor.Name = p.Restaurant.Name
+	}
+	var total float64
+	for _, it := range p.Items {
+		name := strings.TrimSpace(it.Name)
+		if name == "" {
+			continue
+		}
+		l := Line{
+			Name:        name,
+			Quantity:    it.Quantity,
+			Total:       Money{Amount: it.LineTotal(), Currency: currency},
+			Unavailable: !it.IsAvailable(),
+		}
+		for _, m := range it.Modifiers {
+			if mn := strings.TrimSpace(m.Name); mn != "" {
+				l.Options = append(l.Options, mn)
+			}
+		}
+		d.Lines = append(d.Lines, l)
+		d.Items += max(it.Quantity, 1)
+		if !l.Unavailable {
+			total += l.Total.Amount
+		}
+	}
+	d.Total = Money{Amount: total, Currency: currency}
+	return d
+}
+
+func parseTime(s string) time.Time {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return time.Time{}
+	}
+	for _, layout := range []string{time.RFC3339Nano, time.RFC3339} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
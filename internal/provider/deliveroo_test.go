@@ -0,0 +1,91 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/steipete/ordercli/internal/deliveroo"
+)
+
+func TestDeliveroo_History(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/consumer/order-history/v1/orders/o1":
+			_, _ = w.Write([]byte(`{"id":"o1","status":"delivered","total":15,"currency_code":"gbp","restaurant":{"name":"R"},` +
+				`"items":[{"name":"Burger","quantity":2,"total_price":12,"modifiers":[{"name":"Cheese"}]}],"rider_tip":1.5,` +
+				`"delivery_address":"1 High St"}`))
+			return
+		case "/consumer/order-history/v1/orders/o1/reorder":
+			_, _ = w.Write([]byte(`{"order_id":"o1","restaurant_id":"r1","restaurant":{"name":"R"},"currency_code":"gbp","items":[` +
+				`{"item_id":"i1","name":"Burger","quantity":2,"unit_price":6},{"item_id":"i2","name":"Shake","available":false,"unit_price":4}]}`))
+			return
+		case "/consumer/order-history/v1/orders/nope":
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if q.Get("state") == "active" {
+			_, _ = w.Write([]byte(`{"orders":[{"id":"a1","status":"in_kitchen","estimated_delivery_at":"2025-12-20T12:30:00Z"},` +
+				`{"id":"a2","status":"delivered"}]}`))
+			return
+		}
+		if q.Get("offset") != "3" || q.Get("limit") != "2" {
+			t.Fatalf("q=%v", q)
+		}
+		_, _ = w.Write([]byte(`{"count":9,"orders":[{"id":"o1","status":"delivered","submitted_at":"2025-12-20T11:00:00Z","total":12.5,"currency_code":"gbp","restaurant":{"name":"R"}}]}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	c, err := deliveroo.NewClient(deliveroo.ClientOptions{BaseURL: srv.URL, BearerToken: "tok"})
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	p := NewDeliveroo(c, DeliverooOptions{})
+
+	page, err := p.History(context.Background(), HistoryRequest{Offset: 3, Limit: 2})
+	if err != nil {
+		t.Fatalf("History: %v", err)
+	}
+	if page.Total != 9 || len(page.Orders) != 1 {
+		t.Fatalf("unexpected page: %#v", page)
+	}
+	o := page.Orders[0]
+	if o.Provider != DeliverooName || o.Vendor.Name != "R" || o.Total.String() != "12.50 GBP" || o.Time.IsZero() {
+		t.Fatalf("unexpected order: %#v", o)
+	}
+
+	active, err := p.ActiveOrders(context.Background())
+	if err != nil {
+		t.Fatalf("ActiveOrders: %v", err)
+	}
+	if len(active.Orders) != 2 || !active.Orders[0].Active || active.Orders[0].ETA.IsZero() ||
+		active.Orders[0].Status != "preparing" || active.Orders[1].Active {
+		t.Fatalf("unexpected active: %#v", active)
+	}
+
+	d, err := p.Order(context.Background(), "o1")
+	if err != nil {
+		t.Fatalf("Order: %v", err)
+	}
+	if d.Vendor.Name != "R" || d.Items != 2 || len(d.Lines) != 1 || d.Lines[0].Total.String() != "12.00 GBP" ||
+		len(d.Lines[0].Options) != 1 || len(d.Fees) != 1 || d.Fees[0].Name != "rider tip" || d.Address != "1 High St" {
+		t.Fatalf("unexpected detail: %#v", d)
+	}
+	pre, err := p.ReorderPreview(context.Background(), "o1")
+	if err != nil {
+		t.Fatalf("ReorderPreview: %v", err)
+	}
+	if pre.Vendor.ID != "r1" || len(pre.Lines) != 2 || pre.Lines[0].Unavailable || !pre.Lines[1].Unavailable ||
+		pre.Total.String() != "12.00 GBP" || pre.Items != 3 {
+		t.Fatalf("unexpected preview: %#v", pre)
+	}
+	if _, err := p.Order(context.Background(), "nope"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,176 @@
+package provider
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/steipete/ordercli/internal/foodora"
+)
+
+const FoodoraName = "foodora"
+
+type FoodoraOptions struct {
+	// Include is passed to orders/order_history (default: order_products,order_details).
+	Include        string
+	PandaGoEnabled bool
+	// Currency is used for orders that don't name one (the market's currency).
+	Currency string
+}
+
+type Foodora struct {
+	c    *foodora.Client
+	opts FoodoraOptions
+}
+
+func NewFoodora(c *foodora.Client, opts FoodoraOptions) *Foodora {
+	return &Foodora{c: c, opts: opts}
+}
+
+func (f *Foodora) Name() string { return FoodoraName }
+
+func (f *Foodora) History(ctx context.Context, req HistoryRequest) (HistoryPage, error) {
+	resp, err := f.c.OrderHistory(ctx, foodora.OrderHistoryRequest{
+		Include:        f.opts.Include,
+		Offset:         req.Offset,
+		Limit:          req.Limit,
+		PandaGoEnabled: f.opts.PandaGoEnabled,
+	})
+	if err != nil {
+		return HistoryPage{}, err
+	}
+	out := HistoryPage{Total: int(resp.Data.TotalCount)}
+	for _, it := range resp.Data.Items {
+		o := FoodoraHistoryOrder(it)
+		if o.Total.Currency == "" {
+			o.Total.Currency = f.opts.Currency
+		}
+		out.Orders = append(out.Orders, o)
+	}
+	return out, nil
+}
+
+func (f *Foodora) ActiveOrders(ctx context.Context) (ActivePage, error) {
+	resp, err := f.c.ActiveOrders(ctx)
+	if err != nil {
+		return ActivePage{}, err
+	}
+	var out ActivePage
+	if resp.Data.PollInSeconds != nil && *resp.Data.PollInSeconds > 0 {
+		out.PollInterval = time.Duration(*resp.Data.PollInSeconds) * time.Second
+	}
+	for _, o := range resp.Data.ActiveOrders {
+		out.Orders = append(out.Orders, FoodoraActiveOrder(o))
+	}
+	return out, nil
+}
+
+func (f *Foodora) Order(ctx context.Context, id string) (OrderDetail, error) {
+	resp, err := f.c.OrderHistoryByCode(ctx, foodora.OrderHistoryByCodeRequest{
+		OrderCode: id,
+		Include:   f.opts.Include,
+	})
+	if err != nil {
+		return OrderDetail{}, err
+	}
+	if len(resp.Data.Items) == 0 {
+		return OrderDetail{}, ErrNotFound
+	}
+	d := FoodoraOrderDetail(resp.Data.Items[0])
+	d.DefaultCurrency(f.opts.Currency)
+	return d, nil
+}
+
+// ReorderPreview never calls orders/{orderCode}/reorder; it only reads the historical order.
+func (f *Foodora) ReorderPreview(ctx context.Context, id string) (OrderDetail, error) {
+	return f.Order(ctx, id)
+}
+
+// OrderETA reads the delivery estimate from tracking/orders/{orderCode}; zero when absent.
+func (f *Foodora) OrderETA(ctx context.Context, id string) (time.Time, error) {
+	resp, err := f.c.OrderStatus(ctx, id)
+	if err != nil {
+		return time.Time{}, err
+	}
+	eta, _ := resp.Data.ETAWindow()
+	return eta, nil
+}
+
+func FoodoraHistoryOrder(it foodora.OrderHistoryItem) Order {
+	o := Order{
+		Provider: FoodoraName,
+		ID:       it.OrderCode,
+		Total:    Money{Amount: it.TotalValue, Currency: it.CurrencyCode()},
+		Items:    it.ItemCount(),
+	}
+	if it.Vendor != nil {
+		o.Vendor = Vendor{ID: it.Vendor.Code, Name: it.Vendor.Name}
+	}
+	if s := it.CurrentStatus; s != nil {
+		o.Cancelled = s.Cancelled()
+		switch {
+		case s.Message != "":
+			o.Status = s.Message
+		case s.Code != "":
+			o.Status = string(s.Code)
+		default:
+			o.Status = string(s.InternalStatusCode)
+		}
+	}
+	if it.ConfirmedDeliveryTime != nil {
+		o.Time = it.ConfirmedDeliveryTime.Date.Time
+	}
+	return o
+}
+
+func FoodoraActiveOrder(a foodora.ActiveOrder) Order {
+	status := a.Status.Subtitle
+	if status == "" && len(a.Status.Titles) > 0 {
+		status = a.Status.Titles[0].Name
+	}
+	return Order{
+		Provider: FoodoraName,
+		ID:       a.Code,
+		Vendor:   Vendor{ID: a.Vendor.Code, Name: a.Vendor.Name},
+		Status:   status,
+		Active:   !a.IsDelivered,
+	}
+}
+
+// FoodoraOrderDetail normalizes a typed orders/order_history detail item.
+func FoodoraOrderDetail(it foodora.OrderHistoryDetail) OrderDetail {
+	currency := it.CurrencyCode()
+	d := OrderDetail{
+		Order: FoodoraHistoryOrder(foodora.OrderHistoryItem{
+			OrderCode:             it.OrderCode,
+			CurrentStatus:         it.CurrentStatus,
+			ConfirmedDeliveryTime: it.ConfirmedDeliveryTime,
+			Vendor:                it.Vendor,
+		}),
+		Payment: it.PaymentMethod(),
+		Address: it.Address(),
+	}
+	d.Total = Money{Amount: it.Total(), Currency: currency}
+	for _, p := range it.Products {
+		name := p.DisplayName()
+		if name == "" {
+			continue
+		}
+		l := Line{
+			Name:     name,
+			Quantity: int(p.Quantity),
+			Total:    Money{Amount: p.LineTotal(), Currency: currency},
+		}
+		for _, t := range p.Toppings {
+			if tn := strings.TrimSpace(t.Name); tn != "" {
+				l.Options = append(l.Options, tn)
+			}
+		}
+		d.Lines = append(d.Lines, l)
+		d.Items += max(l.Quantity, 1)
+	}
+	for _, f := range it.Fees() {
+		d.Fees = append(d.Fees, Fee{Name: f.Name, Amount: Money{Amount: f.Amount, Currency: currency}})
+	}
+	return d
+}
@@ -0,0 +1,89 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/steipete/ordercli/internal/foodora"
+)
+
+func TestFoodora_HistoryAndOrder(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/orders/order_history":
+			if r.URL.Query().Get("order_code") != "" {
+				_, _ = w.Write([]byte(`{"status":200,"data":{"items":[{"order_code":"X","vendor":{"code":"V","name":"Vendor"},"payment":{"total_value":9.5},"order_address":"Main 1","order_products":[{"title":"Pizza","quantity":"2","total_price":9.5,"toppings":[{"name":"Olives"}]},{"name":""}]}]}}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"status":200,"data":{"total_count":"1","items":[{"order_code":"X","current_status":{"code":7},"confirmed_delivery_time":{"date":"2025-12-20T00:00:00Z"},"vendor":{"code":"V","name":"Vendor"},"total_value":9.5,"currency":"huf","order_products":[{"title":"Pizza","quantity":"2"},{"title":"Cola"}]}]}}`))
+		case "/tracking/active-orders":
+			_, _ = w.Write([]byte(`{"status":200,"data":{"poll_in_sec":5,"active_orders":[{"code":"A","vendor":{"name":"Vendor"},"status_messages":{"titles":[{"name":"Preparing"}]}}]}}`))
+		default:
+			t.Fatalf("path=%s", r.URL.Path)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	c, err := foodora.New(foodora.Options{BaseURL: srv.URL + "/", AccessToken: "tok"})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	p := NewFoodora(c, FoodoraOptions{Currency: "EUR"})
+
+	page, err := p.History(context.Background(), HistoryRequest{Limit: 5})
+	if err != nil {
+		t.Fatalf("History: %v", err)
+	}
+	if page.Total != 1 || len(page.Orders) != 1 {
+		t.Fatalf("unexpected page: %#v", page)
+	}
+	o := page.Orders[0]
+	if o.Provider != FoodoraName || o.ID != "X" || o.Status != "7" || o.Vendor.Name != "Vendor" || o.Total.String() != "9.50 HUF" || o.Items != 3 || o.Time.IsZero() {
+		t.Fatalf("unexpected order: %#v", o)
+	}
+
+	d, err := p.Order(context.Background(), "X")
+	if err != nil {
+		t.Fatalf("Order: %v", err)
+	}
+	// The detail names no currency: the market's is filled in.
+	if d.Total.String() != "9.50 EUR" || d.Lines[0].Total.Currency != "EUR" || d.Address != "Main 1" || len(d.Lines) != 1 || d.Items != 2 {
+		t.Fatalf("unexpected detail: %#v", d)
+	}
+	if l := d.Lines[0]; l.Name != "Pizza" || l.Quantity != 2 || len(l.Options) != 1 || l.Options[0] != "Olives" {
+		t.Fatalf("unexpected line: %#v", l)
+	}
+
+	active, err := p.ActiveOrders(context.Background())
+	if err != nil {
+		t.Fatalf("ActiveOrders: %v", err)
+	}
+	if active.PollInterval.Seconds() != 5 || len(active.Orders) != 1 || active.Orders[0].Status != "Preparing" || !active.Orders[0].Active {
+		t.Fatalf("unexpected active: %#v", active)
+	}
+}
+
+func TestFoodoraHistoryOrder_Cancelled(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		status foodora.OrderHistoryStatus
+		want   bool
+	}{
+		{foodora.OrderHistoryStatus{Code: "ORDER_CANCELLED", Message: "Storniert"}, true},
+		{foodora.OrderHistoryStatus{Code: "12", InternalStatusCode: "vendor_rejected", Message: "Abgelehnt"}, true},
+		{foodora.OrderHistoryStatus{Code: "DELIVERED", Message: "Zugestellt"}, false},
+		// Only codes count; the message is localized free text.
+		{foodora.OrderHistoryStatus{Code: "DELIVERED", Message: "Delivered, cancellation refused"}, false},
+	} {
+		o := FoodoraHistoryOrder(foodora.OrderHistoryItem{OrderCode: "X", CurrentStatus: &tc.status})
+		if o.Cancelled != tc.want || o.Status != tc.status.Message {
+			t.Fatalf("%+v: cancelled=%t status=%q", tc.status, o.Cancelled, o.Status)
+		}
+	}
+}
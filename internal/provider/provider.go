@@ -0,0 +1,180 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+)
+
+// ErrUnsupported is returned when a provider has no implementation for an operation (yet).
+var ErrUnsupported = errors.New("not supported by provider")
+
+// ErrNotFound is returned when a provider has no order with the requested id.
+var ErrNotFound = errors.New("no order found")
+
+// Provider is the provider-neutral surface shared by the CLI commands.
+type Provider interface {
+	Name() string
+	History(ctx context.Context, req HistoryRequest) (HistoryPage, error)
+	ActiveOrders(ctx context.Context) (ActivePage, error)
+	Order(ctx context.Context, id string) (OrderDetail, error)
+	ReorderPreview(ctx context.Context, id string) (OrderDetail, error)
+}
+
+// ETAProvider is implemented by providers whose active-order listing lacks a delivery
+// estimate but can look one up per order (e.g. from tracking data).
+type ETAProvider interface {
+	OrderETA(ctx context.Context, id string) (time.Time, error)
+}
+
+type HistoryRequest struct {
+	Offset int
+	Limit  int
+}
+
+type HistoryPage struct {
+	Orders []Order
+	// Total is the provider-reported total order count (0 when unknown).
+	Total int
+}
+
+type ActivePage struct {
+	Orders []Order
+	// PollInterval is the provider-suggested poll interval (0 when unknown).
+	PollInterval time.Duration
+}
+
+type Order struct {
+	Provider string    `json:"provider"`
+	ID       string    `json:"id"`
+	Vendor   Vendor    `json:"vendor"`
+	Status   string    `json:"status,omitempty"`
+	Time     time.Time `json:"time,omitzero"`
+	ETA      time.Time `json:"eta,omitzero"`
+	Total    Money     `json:"total,omitzero"`
+	Items    int       `json:"item_count,omitempty"`
+	Active   bool      `json:"active,omitempty"`
+	// Cancelled is set from the provider's status code for orders that were cancelled,
+	// rejected or failed; Status is display text and may be localized.
+	Cancelled bool `json:"cancelled,omitempty"`
+	// Submitted is when the order was placed, for providers that report it apart from Time
+	// (delivery time, falling back to submission).
+	Submitted time.Time `json:"submitted_at,omitzero"`
+}
+
+// Delivered reports whether the order went through: neither still active nor cancelled.
+func (o Order) Delivered() bool { return !o.Active && !o.Cancelled }
+
+type Vendor struct {
+	ID   string `json:"id,omitempty"`
+	Name string `json:"name,omitempty"`
+}
+
+type Money struct {
+	Amount   float64 `json:"amount"`
+	Currency string  `json:"currency,omitempty"`
+}
+
+func (m Money) IsZero() bool { return m.Amount == 0 && m.Currency == "" }
+
+func (m Money) String() string {
+	if m.IsZero() {
+		return ""
+	}
+	s := strconv.FormatFloat(m.Amount, 'f', 2, 64)
+	if m.Currency != "" {
+		return s + " " + m.Currency
+	}
+	return s
+}
+
+type OrderDetail struct {
+	Order
+	Lines []Line `json:"lines,omitempty"`
+	// Fees are price components besides the lines (delivery fee, tip, discounts as negative amounts).
+	Fees    []Fee  `json:"fees,omitempty"`
+	Payment string `json:"payment,omitempty"`
+	Address string `json:"address,omitempty"`
+}
+
+// DefaultCurrency sets cur on the total, lines and fees that don't name a currency.
+func (d *OrderDetail) DefaultCurrency(cur string) {
+	if cur == "" {
+		return
+	}
+	if d.Total.Currency == "" {
+		d.Total.Currency = cur
+	}
+	for i := range d.Lines {
+		if d.Lines[i].Total.Currency == "" {
+			d.Lines[i].Total.Currency = cur
+		}
+	}
+	for i := range d.Fees {
+		if d.Fees[i].Amount.Currency == "" {
+			d.Fees[i].Amount.Currency = cur
+		}
+	}
+}
+
+type Fee struct {
+	Name   string `json:"name"`
+	Amount Money  `json:"amount"`
+}
+
+type Line struct {
+	Name     string   `json:"name"`
+	Quantity int      `json:"quantity,omitempty"`
+	Total    Money    `json:"total,omitzero"`
+	Options  []string `json:"options,omitempty"`
+	// Unavailable is set for reorder previews when the item can no longer be ordered.
+	Unavailable bool `json:"unavailable,omitempty"`
+}
+
+// WalkHistory pages through History until limit orders were visited, the provider runs out of
+// orders, or fn returns an error. It returns the number of orders passed to fn.
+func WalkHistory(ctx context.Context, p Provider, limit, pageSize int, fn func(Order) error) (int, error) {
+	return WalkHistoryFrom(ctx, p, 0, limit, pageSize, fn)
+}
+
+// WalkHistoryFrom is WalkHistory starting offset orders into the history.
+func WalkHistoryFrom(ctx context.Context, p Provider, offset, limit, pageSize int, fn func(Order) error) (int, error) {
+	if limit <= 0 {
+		limit = 20
+	}
+	if pageSize <= 0 {
+		pageSize = 20
+	}
+
+	offset = max(offset, 0)
+	seen := 0
+	for seen < limit {
+		reqLimit := min(pageSize, limit-seen)
+		page, err := p.History(ctx, HistoryRequest{Offset: offset, Limit: reqLimit})
+		if err != nil {
+			return seen, err
+		}
+		if len(page.Orders) == 0 {
+			return seen, nil
+		}
+		for _, o := range page.Orders {
+			if seen >= limit {
+				return seen, nil
+			}
+			if err := fn(o); err != nil {
+				return seen, err
+			}
+			seen++
+		}
+
+		offset += len(page.Orders)
+		if page.Total > 0 && offset >= page.Total {
+			return seen, nil
+		}
+		if len(page.Orders) < reqLimit {
+			return seen, nil
+		}
+	}
+	return seen, nil
+}
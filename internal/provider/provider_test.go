@@ -0,0 +1,118 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+type fakeProvider struct {
+	orders []Order
+	total  int
+	calls  []HistoryRequest
+}
+
+func (f *fakeProvider) Name() string { return "fake" }
+
+func (f *fakeProvider) History(ctx context.Context, req HistoryRequest) (HistoryPage, error) {
+	f.calls = append(f.calls, req)
+	end := min(req.Offset+req.Limit, len(f.orders))
+	if req.Offset >= end {
+		return HistoryPage{Total: f.total}, nil
+	}
+	return HistoryPage{Orders: f.orders[req.Offset:end], Total: f.total}, nil
+}
+
+func (f *fakeProvider) ActiveOrders(ctx context.Context) (ActivePage, error) {
+	return ActivePage{}, nil
+}
+
+func (f *fakeProvider) Order(ctx context.Context, id string) (OrderDetail, error) {
+	return OrderDetail{}, ErrUnsupported
+}
+
+func (f *fakeProvider) ReorderPreview(ctx context.Context, id string) (OrderDetail, error) {
+	return OrderDetail{}, ErrUnsupported
+}
+
+func fakeOrders(n int) []Order {
+	out := make([]Order, n)
+	for i := range out {
+		out[i] = Order{Provider: "fake", ID: string(rune('a' + i))}
+	}
+	return out
+}
+
+func TestWalkHistory_PagesUntilLimit(t *testing.T) {
+	t.Parallel()
+	p := &fakeProvider{orders: fakeOrders(7)}
+
+	var ids []string
+	n, err := WalkHistory(context.Background(), p, 5, 2, func(o Order) error {
+		ids = append(ids, o.ID)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("WalkHistory: %v", err)
+	}
+	if n != 5 || len(ids) != 5 || ids[4] != "e" {
+		t.Fatalf("n=%d ids=%v", n, ids)
+	}
+	if len(p.calls) != 3 || p.calls[2].Offset != 4 || p.calls[2].Limit != 1 {
+		t.Fatalf("calls=%#v", p.calls)
+	}
+}
+
+func TestWalkHistory_StopsAtTotal(t *testing.T) {
+	t.Parallel()
+	p := &fakeProvider{orders: fakeOrders(4), total: 4}
+
+	n, err := WalkHistory(context.Background(), p, 100, 2, func(Order) error { return nil })
+	if err != nil {
+		t.Fatalf("WalkHistory: %v", err)
+	}
+	if n != 4 || len(p.calls) != 2 {
+		t.Fatalf("n=%d calls=%d", n, len(p.calls))
+	}
+}
+
+func TestWalkHistory_CallbackError(t *testing.T) {
+	t.Parallel()
+	p := &fakeProvider{orders: fakeOrders(3)}
+	stop := errors.New("stop")
+
+	n, err := WalkHistory(context.Background(), p, 10, 10, func(Order) error { return stop })
+	if !errors.Is(err, stop) || n != 0 {
+		t.Fatalf("n=%d err=%v", n, err)
+	}
+}
+
+func TestMoney_String(t *testing.T) {
+	t.Parallel()
+	if (Money{}).String() != "" {
+		t.Fatalf("expected empty")
+	}
+	if got := (Money{Amount: 12.5, Currency: "EUR"}).String(); got != "12.50 EUR" {
+		t.Fatalf("got %q", got)
+	}
+	if got := (Money{Amount: 3}).String(); got != "3.00" {
+		t.Fatalf("got %q", got)
+	}
+	if got := (Money{Currency: "EUR"}).String(); got != "0.00 EUR" {
+		t.Fatalf("got %q", got)
+	}
+}
+
+func TestWalkHistoryFrom_Offset(t *testing.T) {
+	t.Parallel()
+	p := &fakeProvider{orders: fakeOrders(5), total: 5}
+
+	var ids []string
+	n, err := WalkHistoryFrom(context.Background(), p, 3, 10, 10, func(o Order) error {
+		ids = append(ids, o.ID)
+		return nil
+	})
+	if err != nil || n != 2 || len(ids) != 2 || ids[0] != "d" || p.calls[0].Offset != 3 {
+		t.Fatalf("n=%d ids=%v calls=%#v err=%v", n, ids, p.calls, err)
+	}
+}
@@ -0,0 +1,121 @@
+// Package redact masks secrets and personal data in API payloads before they end up in
+// error messages or recorded cassettes.
+package redact
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+var sensitiveJSONKeys = map[string]struct{}{
+	"access_token":  {},
+	"refresh_token": {},
+	"client_secret": {},
+	"password":      {},
+	"mfa_token":     {},
+	"otp":           {},
+	"x-otp":         {},
+	"address":       {},
+	"bearer_token":  {},
+	"authorization": {},
+	"cookie":        {},
+}
+
+var sensitiveJSONValueRE = regexp.MustCompile(`(?i)("(?:access_token|refresh_token|client_secret|password|mfa_token|otp|bearer_token|authorization|cookie)"\s*:\s*)"[^"]*"`)
+
+// IsSensitiveKey reports whether values under this JSON key (case-insensitive) are masked.
+func IsSensitiveKey(k string) bool {
+	_, ok := sensitiveJSONKeys[strings.ToLower(k)]
+	return ok
+}
+
+// JSON returns b with the values of sensitive keys replaced by "***". Bodies that aren't
+// valid JSON get a best-effort pattern replacement.
+func JSON(b []byte) string {
+	if len(b) == 0 {
+		return ""
+	}
+
+	// UseNumber keeps large ids intact through the round trip.
+	var v any
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	if err := dec.Decode(&v); err == nil && !dec.More() {
+		Value(v)
+		if out, err := json.Marshal(v); err == nil {
+			return string(out)
+		}
+	}
+
+	// Best-effort: redact common JSON patterns in string bodies.
+	s := string(b)
+	return sensitiveJSONValueRE.ReplaceAllString(s, `$1"***"`)
+}
+
+// Value masks sensitive keys in a decoded JSON value in place.
+func Value(v any) {
+	switch t := v.(type) {
+	case map[string]any:
+		for k, vv := range t {
+			if IsSensitiveKey(k) {
+				t[k] = "***"
+				continue
+			}
+			Value(vv)
+		}
+	case []any:
+		for i := range t {
+			Value(t[i])
+		}
+	}
+}
+
+// Snippet is JSON(b) cut to at most n bytes (plus an ellipsis).
+func Snippet(b []byte, n int) string {
+	s := JSON(b)
+	if n > 0 && len(s) > n {
+		s = s[:n] + "…"
+	}
+	return s
+}
+
+// Form masks sensitive keys in a URL-encoded form or query string. Unparseable input is
+// returned unchanged.
+func Form(s string) string {
+	if s == "" {
+		return ""
+	}
+	v, err := url.ParseQuery(s)
+	if err != nil {
+		return s
+	}
+	for k := range v {
+		if IsSensitiveKey(k) {
+			v[k] = []string{"***"}
+		}
+	}
+	return v.Encode()
+}
+
+// sensitiveHeaderParts mark headers carrying credentials (Authorization, Cookie, Set-Cookie,
+// X-Goog-Firebase-Installations-Auth, API keys).
+var sensitiveHeaderParts = []string{"auth", "cookie", "token", "secret", "api-key", "apikey"}
+
+// Header returns a copy of h with credential headers masked.
+func Header(h http.Header) http.Header {
+	out := h.Clone()
+	for k := range out {
+		lk := strings.ToLower(k)
+		for _, p := range sensitiveHeaderParts {
+			if strings.Contains(lk, p) {
+				out[k] = []string{"***"}
+				break
+			}
+		}
+	}
+	return out
+}
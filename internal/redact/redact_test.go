@@ -0,0 +1,83 @@
+package redact
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestJSON(t *testing.T) {
+	t.Parallel()
+
+	raw := []byte(`{
+  "access_token":"a",
+  "refresh_token":"r",
+  "client_secret":"s",
+  "password":"p",
+  "otp":"o",
+  "address":{"id":"123","street":"Main"},
+  "nested":{"mfa_token":"m"}
+}`)
+	out := JSON(raw)
+
+	for _, leak := range []string{`"a"`, `"r"`, `"s"`, `"p"`, `"o"`, `"m"`, "Main"} {
+		if strings.Contains(out, leak) {
+			t.Fatalf("redaction leaked %q: %s", leak, out)
+		}
+	}
+	for _, want := range []string{`"access_token":"***"`, `"refresh_token":"***"`, `"client_secret":"***"`, `"password":"***"`, `"otp":"***"`, `"mfa_token":"***"`} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("expected %q in: %s", want, out)
+		}
+	}
+	if !strings.Contains(out, `"address":"***"`) {
+		t.Fatalf("expected address redaction in: %s", out)
+	}
+}
+
+func TestJSON_NonJSONAndSnippet(t *testing.T) {
+	t.Parallel()
+
+	out := JSON([]byte(`oops {"bearer_token": "abc", "cookie":"c=1"} trailing`))
+	if strings.Contains(out, "abc") || strings.Contains(out, "c=1") {
+		t.Fatalf("redaction leaked: %s", out)
+	}
+	if got := Snippet([]byte(`{"a":"0123456789"}`), 8); got != `{"a":"01…` {
+		t.Fatalf("snippet=%q", got)
+	}
+	if !IsSensitiveKey("Authorization") || IsSensitiveKey("status") {
+		t.Fatalf("IsSensitiveKey")
+	}
+}
+
+func TestJSON_KeepsNumbers(t *testing.T) {
+	t.Parallel()
+
+	if got := JSON([]byte(`{"id":12345678901234567890,"total":12.50}`)); got != `{"id":12345678901234567890,"total":12.50}` {
+		t.Fatalf("got %s", got)
+	}
+}
+
+func TestFormAndHeader(t *testing.T) {
+	t.Parallel()
+
+	if got := Form("grant_type=password&password=hunter2&client_secret=s&username=u"); got != "client_secret=%2A%2A%2A&grant_type=password&password=%2A%2A%2A&username=u" {
+		t.Fatalf("form=%s", got)
+	}
+
+	h := http.Header{}
+	h.Set("Authorization", "Bearer abc")
+	h.Set("Cookie", "sid=1")
+	h.Set("Set-Cookie", "sid=2")
+	h.Set("X-Goog-Firebase-Installations-Auth", "fis")
+	h.Set("Content-Type", "application/json")
+	got := Header(h)
+	for _, k := range []string{"Authorization", "Cookie", "Set-Cookie", "X-Goog-Firebase-Installations-Auth"} {
+		if got.Get(k) != "***" {
+			t.Fatalf("%s=%q", k, got.Get(k))
+		}
+	}
+	if got.Get("Content-Type") != "application/json" || h.Get("Authorization") != "Bearer abc" {
+		t.Fatalf("header=%v original=%v", got, h)
+	}
+}
@@ -0,0 +1,202 @@
+package secrets
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+const (
+	fileVersion       = 1
+	fileKDF           = "pbkdf2-sha256"
+	defaultIterations = 600_000
+)
+
+// fileAAD binds the ciphertext to this format.
+var fileAAD = []byte("ordercli-secrets-v1")
+
+// EncryptedFile keeps secrets in one file encrypted with AES-256-GCM, keyed by a passphrase
+// through PBKDF2-SHA256. The whole set is decrypted on first use and re-encrypted (with a
+// fresh nonce) on every change.
+type EncryptedFile struct {
+	Path       string
+	Passphrase func() (string, error)
+	// Iterations applies to newly created files (default 600000).
+	Iterations int
+
+	mu     sync.Mutex
+	loaded bool
+	values map[string]string
+	key    []byte
+	salt   []byte
+	iter   int
+}
+
+type encryptedFileData struct {
+	Version    int    `json:"version"`
+	KDF        string `json:"kdf"`
+	Iterations int    `json:"iterations"`
+	Salt       []byte `json:"salt"`
+	Nonce      []byte `json:"nonce"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+func (f *EncryptedFile) Get(key string) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.load(); err != nil {
+		return "", err
+	}
+	v, ok := f.values[key]
+	if !ok {
+		return "", ErrNotFound
+	}
+	return v, nil
+}
+
+func (f *EncryptedFile) Set(key, value string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.load(); err != nil {
+		return err
+	}
+	if old, ok := f.values[key]; ok && old == value {
+		return nil
+	}
+	f.values[key] = value
+	return f.write()
+}
+
+func (f *EncryptedFile) Delete(key string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.load(); err != nil {
+		return err
+	}
+	if _, ok := f.values[key]; !ok {
+		return nil
+	}
+	delete(f.values, key)
+	return f.write()
+}
+
+func (f *EncryptedFile) load() error {
+	if f.loaded {
+		return nil
+	}
+	b, err := os.ReadFile(f.Path)
+	if errors.Is(err, os.ErrNotExist) {
+		f.values = map[string]string{}
+		f.loaded = true
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	var data encryptedFileData
+	if err := json.Unmarshal(b, &data); err != nil {
+		return fmt.Errorf("secrets file %s: %w", f.Path, err)
+	}
+	if data.Version != fileVersion || data.KDF != fileKDF || data.Iterations <= 0 {
+		return fmt.Errorf("secrets file %s: unsupported format (version %d, kdf %q)", f.Path, data.Version, data.KDF)
+	}
+	key, err := f.deriveKey(data.Salt, data.Iterations)
+	if err != nil {
+		return err
+	}
+	gcm, err := newGCM(key)
+	if err != nil {
+		return err
+	}
+	plain, err := gcm.Open(nil, data.Nonce, data.Ciphertext, fileAAD)
+	if err != nil {
+		return fmt.Errorf("secrets file %s: wrong passphrase or corrupted file", f.Path)
+	}
+	values := map[string]string{}
+	if err := json.Unmarshal(plain, &values); err != nil {
+		return fmt.Errorf("secrets file %s: %w", f.Path, err)
+	}
+	f.values, f.key, f.salt, f.iter = values, key, data.Salt, data.Iterations
+	f.loaded = true
+	return nil
+}
+
+func (f *EncryptedFile) write() error {
+	if f.key == nil {
+		f.iter = f.Iterations
+		if f.iter <= 0 {
+			f.iter = defaultIterations
+		}
+		f.salt = make([]byte, 16)
+		if _, err := rand.Read(f.salt); err != nil {
+			return err
+		}
+		key, err := f.deriveKey(f.salt, f.iter)
+		if err != nil {
+			return err
+		}
+		f.key = key
+	}
+
+	plain, err := json.Marshal(f.values)
+	if err != nil {
+		return err
+	}
+	gcm, err := newGCM(f.key)
+	if err != nil {
+		return err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return err
+	}
+	b, err := json.MarshalIndent(encryptedFileData{
+		Version:    fileVersion,
+		KDF:        fileKDF,
+		Iterations: f.iter,
+		Salt:       f.salt,
+		Nonce:      nonce,
+		Ciphertext: gcm.Seal(nil, nonce, plain, fileAAD),
+	}, "", "  ")
+	if err != nil {
+		return err
+	}
+	b = append(b, '\n')
+
+	if err := os.MkdirAll(filepath.Dir(f.Path), 0o755); err != nil {
+		return err
+	}
+	tmp := f.Path + ".tmp"
+	if err := os.WriteFile(tmp, b, 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, f.Path)
+}
+
+func (f *EncryptedFile) deriveKey(salt []byte, iter int) ([]byte, error) {
+	pass, err := f.Passphrase()
+	if err != nil {
+		return nil, err
+	}
+	if pass == "" {
+		return nil, errors.New("empty secrets passphrase")
+	}
+	return pbkdf2.Key(sha256.New, pass, salt, iter, 32)
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,65 @@
+package secrets
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestEncryptedFile(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "secrets.enc")
+	pass := func() (string, error) { return "correct horse", nil }
+	f := &EncryptedFile{Path: path, Passphrase: pass, Iterations: 1000}
+
+	if _, err := f.Get("a"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("want ErrNotFound, got %v", err)
+	}
+	if err := f.Set("default/foodora/access_token", "tok-123"); err != nil {
+		t.Fatalf("set: %v", err)
+	}
+	if err := f.Set("default/foodora/refresh_token", "ref-456"); err != nil {
+		t.Fatalf("set: %v", err)
+	}
+	if err := f.Delete("default/foodora/refresh_token"); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	if strings.Contains(string(b), "tok-123") || strings.Contains(string(b), "access_token") {
+		t.Fatalf("plaintext in file: %s", b)
+	}
+	if st, _ := os.Stat(path); st.Mode().Perm() != 0o600 {
+		t.Fatalf("perm=%o", st.Mode().Perm())
+	}
+
+	// A fresh instance reads what the first one wrote.
+	g := &EncryptedFile{Path: path, Passphrase: pass}
+	if v, err := g.Get("default/foodora/access_token"); err != nil || v != "tok-123" {
+		t.Fatalf("get=%q err=%v", v, err)
+	}
+	if _, err := g.Get("default/foodora/refresh_token"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("deleted value still present: %v", err)
+	}
+
+	wrong := &EncryptedFile{Path: path, Passphrase: func() (string, error) { return "wrong", nil }}
+	if _, err := wrong.Get("default/foodora/access_token"); err == nil || !strings.Contains(err.Error(), "wrong passphrase") {
+		t.Fatalf("want wrong passphrase error, got %v", err)
+	}
+}
+
+func TestOpen_UnknownBackend(t *testing.T) {
+	t.Parallel()
+	if _, err := Open(Options{Backend: "vault"}); err == nil {
+		t.Fatalf("expected error")
+	}
+	if _, err := Open(Options{Backend: BackendFile}); err == nil {
+		t.Fatalf("expected error for missing path")
+	}
+}
@@ -0,0 +1,153 @@
+package secrets
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// Keyring stores secrets in the OS keyring through its command line tool: secret-tool
+// (Secret Service, e.g. GNOME Keyring/KWallet) on Linux and BSD, security (Keychain) on macOS.
+// Secret values are passed on stdin, never as arguments.
+type Keyring struct {
+	Service string
+	// Run executes a command; tests replace it.
+	Run func(ctx context.Context, name string, args []string, stdin string) (stdout []byte, err error)
+	// Timeout bounds a single command (default 10s; unlocking may prompt the user).
+	Timeout time.Duration
+
+	tool string
+}
+
+// errExit marks a command that ran but exited non-zero: "not found", but also a locked
+// keyring, a cancelled unlock prompt or a denied permission.
+type errExit struct {
+	err    error
+	code   int
+	stderr string
+}
+
+func (e errExit) Error() string {
+	if e.stderr != "" {
+		return fmt.Sprintf("%v: %s", e.err, e.stderr)
+	}
+	return e.err.Error()
+}
+
+func (e errExit) Unwrap() error { return e.err }
+
+// NewKeyring picks the keyring tool for this OS.
+func NewKeyring(service string) (*Keyring, error) {
+	k := &Keyring{Service: service, Run: runCommand}
+	switch runtime.GOOS {
+	case "darwin":
+		k.tool = "security"
+	case "linux", "freebsd", "openbsd", "netbsd":
+		k.tool = "secret-tool"
+	default:
+		return nil, fmt.Errorf("no keyring support on %s (use the file backend)", runtime.GOOS)
+	}
+	if _, err := exec.LookPath(k.tool); err != nil {
+		return nil, fmt.Errorf("keyring: %s not found (install it or use the file backend)", k.tool)
+	}
+	return k, nil
+}
+
+func runCommand(ctx context.Context, name string, args []string, stdin string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Stdin = strings.NewReader(stdin)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	var ee *exec.ExitError
+	if errors.As(err, &ee) {
+		return out, errExit{err: err, code: ee.ExitCode(), stderr: strings.TrimSpace(stderr.String())}
+	}
+	return out, err
+}
+
+func (k *Keyring) run(args []string, stdin string) ([]byte, error) {
+	timeout := k.Timeout
+	if timeout <= 0 {
+		timeout = 10 * time.Second
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	return k.Run(ctx, k.tool, args, stdin)
+}
+
+func (k *Keyring) Get(key string) (string, error) {
+	var out []byte
+	var err error
+	if k.tool == "security" {
+		out, err = k.run([]string{"find-generic-password", "-s", k.Service, "-a", key, "-w"}, "")
+	} else {
+		out, err = k.run([]string{"lookup", "service", k.Service, "account", key}, "")
+	}
+	if err != nil {
+		if k.notFound(err) {
+			return "", ErrNotFound
+		}
+		return "", fmt.Errorf("keyring: read %s: %w", key, err)
+	}
+	v := strings.TrimSuffix(string(out), "\n")
+	if v == "" && k.tool == "secret-tool" {
+		// secret-tool exits 0 with no output for unknown attributes on some versions.
+		return "", ErrNotFound
+	}
+	return v, nil
+}
+
+func (k *Keyring) Set(key, value string) error {
+	var err error
+	if k.tool == "security" {
+		// `security -i` reads commands from stdin, so the value doesn't show up in ps.
+		line := "add-generic-password -U -s " + quote(k.Service) + " -a " + quote(key) + " -w " + quote(value) + "\n"
+		_, err = k.run([]string{"-i"}, line)
+	} else {
+		_, err = k.run([]string{"store", "--label", k.Service + " " + key, "service", k.Service, "account", key}, value)
+	}
+	if err != nil {
+		return fmt.Errorf("keyring: store %s: %w", key, err)
+	}
+	return nil
+}
+
+func (k *Keyring) Delete(key string) error {
+	var err error
+	if k.tool == "security" {
+		_, err = k.run([]string{"delete-generic-password", "-s", k.Service, "-a", key}, "")
+		if k.notFound(err) {
+			return nil // already gone
+		}
+	} else {
+		_, err = k.run([]string{"clear", "service", k.Service, "account", key}, "")
+	}
+	if err != nil {
+		return fmt.Errorf("keyring: delete %s: %w", key, err)
+	}
+	return nil
+}
+
+// notFound reports the tools' "no such item" exit: security exits 44 (errSecItemNotFound),
+// secret-tool exits 1 without a message. Anything else (locked, cancelled, denied) is an error.
+func (k *Keyring) notFound(err error) bool {
+	var ee errExit
+	if !errors.As(err, &ee) {
+		return false
+	}
+	if k.tool == "security" {
+		return ee.code == 44
+	}
+	return ee.code == 1 && ee.stderr == ""
+}
+
+// quote escapes a word for `security -i`, which splits its input like a shell.
+func quote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
@@ -0,0 +1,112 @@
+package secrets
+
+import (
+	"context"
+	"errors"
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+// fakeSecretTool mimics secret-tool's lookup/store/clear on an in-memory map.
+type fakeSecretTool struct {
+	values map[string]string
+	calls  []string
+}
+
+func (f *fakeSecretTool) run(_ context.Context, name string, args []string, stdin string) ([]byte, error) {
+	f.calls = append(f.calls, name+" "+strings.Join(args, " "))
+	account := args[len(args)-1]
+	switch args[0] {
+	case "lookup":
+		v, ok := f.values[account]
+		if !ok {
+			return nil, errExit{err: &exec.ExitError{}, code: 1}
+		}
+		return []byte(v), nil
+	case "store":
+		f.values[account] = stdin
+	case "clear":
+		delete(f.values, account)
+	}
+	return nil, nil
+}
+
+func TestKeyring_SecretTool(t *testing.T) {
+	t.Parallel()
+
+	fake := &fakeSecretTool{values: map[string]string{}}
+	k := &Keyring{Service: "ordercli", Run: fake.run, tool: "secret-tool"}
+
+	if _, err := k.Get("default/foodora/access_token"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("want ErrNotFound, got %v", err)
+	}
+	if err := k.Set("default/foodora/access_token", "tok-123"); err != nil {
+		t.Fatalf("set: %v", err)
+	}
+	if v, err := k.Get("default/foodora/access_token"); err != nil || v != "tok-123" {
+		t.Fatalf("get=%q err=%v", v, err)
+	}
+	for _, c := range fake.calls {
+		if strings.Contains(c, "tok-123") {
+			t.Fatalf("secret passed as argument: %s", c)
+		}
+	}
+	if err := k.Delete("default/foodora/access_token"); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	if len(fake.values) != 0 {
+		t.Fatalf("values=%v", fake.values)
+	}
+}
+
+func TestKeyring_SecurityQuotesStdin(t *testing.T) {
+	t.Parallel()
+
+	var gotArgs []string
+	var gotStdin string
+	k := &Keyring{Service: "ordercli", tool: "security", Run: func(_ context.Context, name string, args []string, stdin string) ([]byte, error) {
+		gotArgs, gotStdin = args, stdin
+		return nil, nil
+	}}
+	if err := k.Set("default/foodora/cookie/www.foodora.at", `a "b" \c`); err != nil {
+		t.Fatalf("set: %v", err)
+	}
+	if strings.Join(gotArgs, " ") != "-i" {
+		t.Fatalf("args=%v", gotArgs)
+	}
+	want := `add-generic-password -U -s "ordercli" -a "default/foodora/cookie/www.foodora.at" -w "a \"b\" \\c"` + "\n"
+	if gotStdin != want {
+		t.Fatalf("stdin=%q\nwant=%q", gotStdin, want)
+	}
+}
+
+func TestKeyring_OnlyMissingItemsAreNotFound(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		tool     string
+		exit     errExit
+		notFound bool
+	}{
+		{"secret-tool", errExit{err: &exec.ExitError{}, code: 1}, true},
+		{"secret-tool", errExit{err: &exec.ExitError{}, code: 1, stderr: "secret-tool: Cannot get secret of a locked object"}, false},
+		{"secret-tool", errExit{err: &exec.ExitError{}, code: 2}, false},
+		{"security", errExit{err: &exec.ExitError{}, code: 44, stderr: "The specified item could not be found in the keychain."}, true},
+		{"security", errExit{err: &exec.ExitError{}, code: 51, stderr: "User canceled the operation."}, false},
+		{"security", errExit{err: &exec.ExitError{}, code: 36, stderr: "User interaction is not allowed."}, false},
+	} {
+		k := &Keyring{Service: "ordercli", tool: tc.tool, Run: func(context.Context, string, []string, string) ([]byte, error) {
+			return nil, tc.exit
+		}}
+		_, err := k.Get("default/foodora/access_token")
+		if errors.Is(err, ErrNotFound) != tc.notFound || err == nil {
+			t.Fatalf("%s exit %d %q: err=%v", tc.tool, tc.exit.code, tc.exit.stderr, err)
+		}
+		if tc.tool == "security" {
+			if err := k.Delete("default/foodora/access_token"); (err == nil) != tc.notFound {
+				t.Fatalf("delete: exit %d: err=%v", tc.exit.code, err)
+			}
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package secrets
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrNotFound is returned by Get when a store has no value for the key.
+var ErrNotFound = errors.New("secret not found")
+
+// Store keeps secret values outside the config file. Keys look like
+// "default/foodora/access_token".
+type Store interface {
+	Get(key string) (string, error)
+	Set(key, value string) error
+	Delete(key string) error
+}
+
+// Backend names as stored in the config.
+const (
+	BackendKeyring = "keyring"
+	BackendFile    = "file"
+)
+
+// Options configure Open.
+type Options struct {
+	Backend string
+	// Path is the encrypted secrets file (file backend).
+	Path string
+	// Passphrase is called once, when the file backend first needs its key.
+	Passphrase func() (string, error)
+}
+
+// Open returns the store for a backend.
+func Open(opts Options) (Store, error) {
+	switch opts.Backend {
+	case BackendKeyring:
+		return NewKeyring("ordercli")
+	case BackendFile:
+		if opts.Path == "" {
+			return nil, errors.New("secrets file path not set")
+		}
+		if opts.Passphrase == nil {
+			return nil, errors.New("secrets file needs a passphrase")
+		}
+		return &EncryptedFile{Path: opts.Path, Passphrase: opts.Passphrase}, nil
+	default:
+		return nil, fmt.Errorf("unknown secrets backend %q (want %s or %s)", opts.Backend, BackendKeyring, BackendFile)
+	}
+}
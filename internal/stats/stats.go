@@ -0,0 +1,178 @@
+package stats
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/steipete/ordercli/internal/provider"
+)
+
+type Summary struct {
+	Count   int     `json:"count"`
+	Total   float64 `json:"total"`
+	Average float64 `json:"average"`
+	Median  float64 `json:"median"`
+	Min     float64 `json:"min"`
+	Max     float64 `json:"max"`
+}
+
+type Group struct {
+	Key string `json:"key"`
+	Summary
+}
+
+type Report struct {
+	Summary
+	First      time.Time `json:"first,omitzero"`
+	Last       time.Time `json:"last,omitzero"`
+	Currencies []string  `json:"currencies,omitempty"`
+	// Skipped counts orders left out because they were cancelled or are still active.
+	Skipped int `json:"skipped,omitempty"`
+
+	ByMonth    []Group `json:"by_month"`
+	ByWeek     []Group `json:"by_week"`
+	ByWeekday  []Group `json:"by_weekday"`
+	ByHour     []Group `json:"by_hour"`
+	ByVendor   []Group `json:"by_vendor"`
+	ByProvider []Group `json:"by_provider"`
+}
+
+// Compute aggregates the totals of delivered orders. Time buckets use loc; orders without a
+// time only count towards the overall, vendor and provider groups. Amounts are summed as-is:
+// callers check Currencies before mixing them.
+func Compute(orders []provider.Order, loc *time.Location) Report {
+	if loc == nil {
+		loc = time.Local
+	}
+
+	var all []float64
+	var r Report
+	currencies := map[string]bool{}
+	month := newBuckets()
+	week := newBuckets()
+	weekday := newBuckets()
+	hour := newBuckets()
+	vendor := newBuckets()
+	prov := newBuckets()
+
+	for _, o := range orders {
+		if !o.Delivered() {
+			r.Skipped++
+			continue
+		}
+		v := o.Total.Amount
+		all = append(all, v)
+		if c := strings.ToUpper(o.Total.Currency); c != "" {
+			currencies[c] = true
+		}
+		vendor.add(vendorKey(o.Vendor), v)
+		prov.add(o.Provider, v)
+
+		if o.Time.IsZero() {
+			continue
+		}
+		t := o.Time.In(loc)
+		if r.First.IsZero() || t.Before(r.First) {
+			r.First = t
+		}
+		if r.Last.IsZero() || t.After(r.Last) {
+			r.Last = t
+		}
+		y, w := t.ISOWeek()
+		month.add(t.Format("2006-01"), v)
+		week.add(fmt.Sprintf("%04d-W%02d", y, w), v)
+		weekday.add(weekdayKey(t.Weekday()), v)
+		hour.add(fmt.Sprintf("%02d", t.Hour()), v)
+	}
+
+	r.Summary = summarize(all)
+	for c := range currencies {
+		r.Currencies = append(r.Currencies, c)
+	}
+	sort.Strings(r.Currencies)
+
+	r.ByMonth = month.groups(byKey)
+	r.ByWeek = week.groups(byKey)
+	r.ByWeekday = weekday.groups(byKey)
+	r.ByHour = hour.groups(byKey)
+	r.ByVendor = vendor.groups(byTotal)
+	r.ByProvider = prov.groups(byKey)
+	return r
+}
+
+// TopVendors returns the n vendors with the highest total spend (n <= 0 returns all).
+func (r Report) TopVendors(n int) []Group {
+	if n <= 0 || n >= len(r.ByVendor) {
+		return r.ByVendor
+	}
+	return r.ByVendor[:n]
+}
+
+// weekdayKey prefixes the ISO day number so lexical order is Monday..Sunday.
+func weekdayKey(d time.Weekday) string {
+	n := int(d)
+	if n == 0 {
+		n = 7
+	}
+	return fmt.Sprintf("%d-%s", n, d.String()[:3])
+}
+
+func vendorKey(v provider.Vendor) string {
+	if name := strings.TrimSpace(v.Name); name != "" {
+		return name
+	}
+	if v.ID != "" {
+		return v.ID
+	}
+	return "<unknown>"
+}
+
+type buckets map[string][]float64
+
+func newBuckets() buckets { return buckets{} }
+
+func (b buckets) add(key string, v float64) { b[key] = append(b[key], v) }
+
+type groupOrder int
+
+const (
+	byKey groupOrder = iota
+	byTotal
+)
+
+func (b buckets) groups(order groupOrder) []Group {
+	out := make([]Group, 0, len(b))
+	for k, vs := range b {
+		out = append(out, Group{Key: k, Summary: summarize(vs)})
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if order == byTotal && out[i].Total != out[j].Total {
+			return out[i].Total > out[j].Total
+		}
+		return out[i].Key < out[j].Key
+	})
+	return out
+}
+
+func summarize(vs []float64) Summary {
+	if len(vs) == 0 {
+		return Summary{}
+	}
+	sorted := append([]float64(nil), vs...)
+	sort.Float64s(sorted)
+
+	s := Summary{Count: len(sorted), Min: sorted[0], Max: sorted[len(sorted)-1]}
+	for _, v := range sorted {
+		s.Total += v
+	}
+	s.Average = s.Total / float64(s.Count)
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 1 {
+		s.Median = sorted[mid]
+	} else {
+		s.Median = (sorted[mid-1] + sorted[mid]) / 2
+	}
+	return s
+}
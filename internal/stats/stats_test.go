@@ -0,0 +1,64 @@
+package stats
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/steipete/ordercli/internal/provider"
+)
+
+func TestCompute(t *testing.T) {
+	t.Parallel()
+	// 2025-12-15 is a Monday.
+	mon := time.Date(2025, 12, 15, 12, 30, 0, 0, time.UTC)
+	orders := []provider.Order{
+		{Provider: "foodora", Vendor: provider.Vendor{Name: "A"}, Time: mon, Total: provider.Money{Amount: 10}},
+		{Provider: "foodora", Vendor: provider.Vendor{Name: "A"}, Time: mon.Add(24 * time.Hour), Total: provider.Money{Amount: 20}},
+		{Provider: "deliveroo", Vendor: provider.Vendor{Name: "B"}, Time: mon.AddDate(0, -1, 0), Total: provider.Money{Amount: 5, Currency: "gbp"}},
+		{Provider: "deliveroo", Total: provider.Money{Amount: 1}},
+		{Provider: "foodora", Vendor: provider.Vendor{Name: "C"}, Time: mon, Total: provider.Money{Amount: 50}, Cancelled: true},
+		{Provider: "foodora", Vendor: provider.Vendor{Name: "C"}, Time: mon, Total: provider.Money{Amount: 40}, Active: true},
+	}
+
+	r := Compute(orders, time.UTC)
+	if r.Count != 4 || r.Skipped != 2 || r.Total != 36 || r.Median != 7.5 || r.Min != 1 || r.Max != 20 || r.Average != 9 {
+		t.Fatalf("unexpected summary: %#v", r.Summary)
+	}
+	if !r.First.Equal(mon.AddDate(0, -1, 0)) || !r.Last.Equal(mon.Add(24*time.Hour)) {
+		t.Fatalf("first=%v last=%v", r.First, r.Last)
+	}
+	if len(r.Currencies) != 1 || r.Currencies[0] != "GBP" {
+		t.Fatalf("currencies=%v", r.Currencies)
+	}
+
+	if len(r.ByMonth) != 2 || r.ByMonth[0].Key != "2025-11" || r.ByMonth[1].Key != "2025-12" || r.ByMonth[1].Total != 30 {
+		t.Fatalf("by month: %#v", r.ByMonth)
+	}
+	if len(r.ByWeek) != 2 || r.ByWeek[1].Key != "2025-W51" || r.ByWeek[1].Count != 2 {
+		t.Fatalf("by week: %#v", r.ByWeek)
+	}
+	if r.ByWeekday[0].Key != "1-Mon" || r.ByWeekday[0].Total != 10 {
+		t.Fatalf("by weekday: %#v", r.ByWeekday)
+	}
+	if len(r.ByHour) != 1 || r.ByHour[0].Key != "12" || r.ByHour[0].Count != 3 {
+		t.Fatalf("by hour: %#v", r.ByHour)
+	}
+	if top := r.TopVendors(1); len(top) != 1 || top[0].Key != "A" || top[0].Average != 15 {
+		t.Fatalf("top vendors: %#v", top)
+	}
+	if len(r.ByVendor) != 3 || r.ByVendor[2].Key != "<unknown>" {
+		t.Fatalf("by vendor: %#v", r.ByVendor)
+	}
+	if len(r.ByProvider) != 2 || r.ByProvider[0].Key != "deliveroo" || r.ByProvider[0].Count != 2 {
+		t.Fatalf("by provider: %#v", r.ByProvider)
+	}
+}
+
+func TestCompute_Empty(t *testing.T) {
+	t.Parallel()
+	r := Compute(nil, nil)
+	if r.Count != 0 || r.Total != 0 || math.IsNaN(r.Average) || len(r.ByVendor) != 0 {
+		t.Fatalf("unexpected: %#v", r)
+	}
+}
@@ -0,0 +1,301 @@
+// Package transport is the HTTP layer shared by the provider clients: idempotent requests
+// are retried on 429, 5xx and network errors with jittered exponential backoff, server
+// rate-limit hints (Retry-After, RateLimit-Reset) are honored, and every host gets a request
+// budget so long paging runs don't hammer an API.
+package transport
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Options configures a Transport. The zero value neither retries nor limits; see Defaults.
+type Options struct {
+	// Retries is how often a failed GET or HEAD is retried after the first attempt.
+	Retries int
+	// BaseDelay is the backoff before the first retry, doubled per attempt up to MaxDelay
+	// (defaults 500ms and 10s). The actual delay is jittered between half and all of it.
+	BaseDelay time.Duration
+	MaxDelay  time.Duration
+	// MaxWait caps how long a server hint may pause us (default 1m). A longer Retry-After is
+	// not waited for: the response is returned as is.
+	MaxWait time.Duration
+	// Rate is the per-host budget in requests per second (0 = unlimited); Burst is how many
+	// requests may go out back to back (default: Rate rounded up).
+	Rate  float64
+	Burst int
+	// Timeout bounds each attempt, including reading the body (default 20s).
+	Timeout time.Duration
+	// Base does the actual round trips (default http.DefaultTransport).
+	Base http.RoundTripper
+}
+
+// Defaults is what the CLI uses unless flags say otherwise.
+func Defaults() Options {
+	return Options{
+		Retries:   3,
+		BaseDelay: 500 * time.Millisecond,
+		MaxDelay:  10 * time.Second,
+		MaxWait:   time.Minute,
+		Rate:      5,
+		Timeout:   20 * time.Second,
+	}
+}
+
+// Transport is an http.RoundTripper; it is safe for concurrent use and shares the per-host
+// budget across all clients built on it.
+type Transport struct {
+	opts Options
+
+	mu    sync.Mutex
+	hosts map[string]*host
+}
+
+// Package vars so tests don't have to wait.
+var (
+	now    = time.Now
+	sleep  = sleepCtx
+	jitter = func(n int64) int64 { return rand.Int64N(n) }
+)
+
+func New(opts Options) *Transport {
+	if opts.BaseDelay <= 0 {
+		opts.BaseDelay = 500 * time.Millisecond
+	}
+	if opts.MaxDelay <= 0 {
+		opts.MaxDelay = 10 * time.Second
+	}
+	if opts.MaxWait <= 0 {
+		opts.MaxWait = time.Minute
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = 20 * time.Second
+	}
+	if opts.Rate > 0 && opts.Burst <= 0 {
+		opts.Burst = max(int(opts.Rate+0.999), 1)
+	}
+	if opts.Base == nil {
+		opts.Base = http.DefaultTransport
+	}
+	return &Transport{opts: opts, hosts: map[string]*host{}}
+}
+
+// Client returns an http.Client using t. It has no overall timeout: Options.Timeout applies
+// per attempt, so backoff doesn't eat into the request's time.
+func (t *Transport) Client() *http.Client {
+	return &http.Client{Transport: t}
+}
+
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	h := t.host(req.URL.Host)
+	retries := 0
+	if req.Method == http.MethodGet || req.Method == http.MethodHead {
+		retries = max(t.opts.Retries, 0)
+	}
+
+	for attempt := 0; ; attempt++ {
+		if err := h.take(ctx, t.opts.Rate, t.opts.Burst); err != nil {
+			return nil, err
+		}
+		resp, err := t.do(req)
+		if err != nil && (ctx.Err() != nil || !isNetError(err)) {
+			return nil, err
+		}
+		if err == nil {
+			h.observe(resp.Header, t.opts.MaxWait)
+			if resp.StatusCode != http.StatusTooManyRequests && resp.StatusCode < 500 {
+				return resp, nil
+			}
+		}
+		if attempt >= retries {
+			return resp, err
+		}
+
+		d := t.backoff(attempt)
+		if err == nil {
+			if hint, ok := serverWait(resp.Header, now()); ok {
+				if hint > t.opts.MaxWait {
+					return resp, nil
+				}
+				// Everyone talking to this host waits, not just this request.
+				h.pause(hint)
+				d = hint
+			}
+			_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+			_ = resp.Body.Close()
+		}
+		if err := sleep(ctx, d); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// do makes one attempt with its own timeout; the timeout is released when the body is closed.
+func (t *Transport) do(req *http.Request) (*http.Response, error) {
+	ctx, cancel := context.WithTimeout(req.Context(), t.opts.Timeout)
+	resp, err := t.opts.Base.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	resp.Body = &cancelBody{ReadCloser: resp.Body, cancel: cancel}
+	return resp, nil
+}
+
+// backoff is BaseDelay doubled per attempt, capped at MaxDelay, with "equal jitter": half
+// fixed, half random.
+func (t *Transport) backoff(attempt int) time.Duration {
+	d := t.opts.MaxDelay
+	if attempt < 30 {
+		d = min(t.opts.BaseDelay<<attempt, t.opts.MaxDelay)
+	}
+	half := d / 2
+	if half <= 0 {
+		return d
+	}
+	return half + time.Duration(jitter(int64(half)+1))
+}
+
+func (t *Transport) host(name string) *host {
+	name = strings.ToLower(name)
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	h, ok := t.hosts[name]
+	if !ok {
+		h = &host{tokens: float64(t.opts.Burst)}
+		t.hosts[name] = h
+	}
+	return h
+}
+
+// host is a token bucket plus a pause set from rate-limit hints.
+type host struct {
+	mu          sync.Mutex
+	tokens      float64
+	last        time.Time
+	pausedUntil time.Time
+}
+
+// take blocks until the host is not paused and, with a rate, a token is available.
+func (h *host) take(ctx context.Context, rate float64, burst int) error {
+	for {
+		d := h.reserve(rate, burst)
+		if d <= 0 {
+			return nil
+		}
+		if err := sleep(ctx, d); err != nil {
+			return err
+		}
+	}
+}
+
+// reserve takes a token and returns 0, or returns how long to wait before trying again.
+func (h *host) reserve(rate float64, burst int) time.Duration {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	n := now()
+	if n.Before(h.pausedUntil) {
+		return h.pausedUntil.Sub(n)
+	}
+	if rate <= 0 {
+		return 0
+	}
+	if !h.last.IsZero() {
+		h.tokens = min(h.tokens+n.Sub(h.last).Seconds()*rate, float64(burst))
+	}
+	h.last = n
+	if h.tokens >= 1 {
+		h.tokens--
+		return 0
+	}
+	return time.Duration((1 - h.tokens) / rate * float64(time.Second))
+}
+
+func (h *host) pause(d time.Duration) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if until := now().Add(d); until.After(h.pausedUntil) {
+		h.pausedUntil = until
+	}
+}
+
+// observe pauses the host when a response says the quota is used up until the reset.
+func (h *host) observe(hdr http.Header, maxWait time.Duration) {
+	if strings.TrimSpace(hdr.Get("RateLimit-Remaining")) != "0" && strings.TrimSpace(hdr.Get("X-RateLimit-Remaining")) != "0" {
+		return
+	}
+	if d, ok := rateLimitReset(hdr, now()); ok {
+		h.pause(min(d, maxWait))
+	}
+}
+
+// serverWait reads Retry-After (seconds or an HTTP date), then RateLimit-Reset.
+func serverWait(hdr http.Header, n time.Time) (time.Duration, bool) {
+	if v := strings.TrimSpace(hdr.Get("Retry-After")); v != "" {
+		if s, err := strconv.Atoi(v); err == nil {
+			return time.Duration(max(s, 0)) * time.Second, true
+		}
+		if t, err := http.ParseTime(v); err == nil {
+			return max(t.Sub(n), 0), true
+		}
+	}
+	return rateLimitReset(hdr, n)
+}
+
+// rateLimitReset reads RateLimit-Reset / X-RateLimit-Reset: seconds until the reset, or a
+// Unix timestamp for large values (both are in the wild).
+func rateLimitReset(hdr http.Header, n time.Time) (time.Duration, bool) {
+	for _, k := range []string{"RateLimit-Reset", "X-RateLimit-Reset"} {
+		v := strings.TrimSpace(hdr.Get(k))
+		if v == "" {
+			continue
+		}
+		s, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || s < 0 {
+			continue
+		}
+		if s > 1e9 {
+			return max(time.Unix(s, 0).Sub(n), 0), true
+		}
+		return time.Duration(s) * time.Second, true
+	}
+	return 0, false
+}
+
+// isNetError reports connection-level failures (resets, timeouts, DNS, truncated responses);
+// anything else won't get better by retrying.
+func isNetError(err error) bool {
+	var ne net.Error
+	return errors.As(err, &ne) || errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+type cancelBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
+
+func sleepCtx(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
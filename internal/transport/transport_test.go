@@ -0,0 +1,277 @@
+package transport
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+)
+
+// stubSleep records waits and advances a fake clock instead of sleeping; tests using it
+// can't run in parallel.
+func stubSleep(t *testing.T) *[]time.Duration {
+	t.Helper()
+	var waits []time.Duration
+	clock := time.Now()
+	prevSleep, prevNow := sleep, now
+	now = func() time.Time { return clock }
+	sleep = func(ctx context.Context, d time.Duration) error {
+		waits = append(waits, d)
+		clock = clock.Add(d)
+		return ctx.Err()
+	}
+	t.Cleanup(func() { sleep, now = prevSleep, prevNow })
+	return &waits
+}
+
+func TestTransport_RetriesGET(t *testing.T) {
+	waits := stubSleep(t)
+
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch calls.Add(1) {
+		case 1:
+			w.WriteHeader(http.StatusBadGateway)
+		case 2:
+			w.WriteHeader(http.StatusTooManyRequests)
+		default:
+			_, _ = w.Write([]byte("ok"))
+		}
+	}))
+	defer srv.Close()
+
+	cl := New(Options{Retries: 3, BaseDelay: 100 * time.Millisecond}).Client()
+	resp, err := cl.Get(srv.URL)
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	b, _ := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || string(b) != "ok" || calls.Load() != 3 {
+		t.Fatalf("status=%d body=%q calls=%d", resp.StatusCode, b, calls.Load())
+	}
+	if len(*waits) != 2 {
+		t.Fatalf("waits=%v", *waits)
+	}
+	// Equal jitter: between half and all of 100ms, then of 200ms.
+	for i, d := range *waits {
+		full := 100 * time.Millisecond << i
+		if d < full/2 || d > full {
+			t.Fatalf("wait %d=%v", i, d)
+		}
+	}
+}
+
+func TestTransport_GivesUp(t *testing.T) {
+	stubSleep(t)
+
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+		_, _ = w.Write([]byte("down"))
+	}))
+	defer srv.Close()
+
+	cl := New(Options{Retries: 2}).Client()
+	resp, err := cl.Get(srv.URL)
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	b, _ := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	// The last response is handed back intact for the client's error handling.
+	if resp.StatusCode != http.StatusServiceUnavailable || string(b) != "down" || calls.Load() != 3 {
+		t.Fatalf("status=%d body=%q calls=%d", resp.StatusCode, b, calls.Load())
+	}
+}
+
+func TestTransport_DoesNotRetryWrites(t *testing.T) {
+	stubSleep(t)
+
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	cl := New(Options{Retries: 3}).Client()
+	for _, method := range []string{http.MethodPost, http.MethodPut} {
+		req, _ := http.NewRequest(method, srv.URL, strings.NewReader("{}"))
+		resp, err := cl.Do(req)
+		if err != nil {
+			t.Fatalf("%s: %v", method, err)
+		}
+		_ = resp.Body.Close()
+	}
+	if calls.Load() != 2 {
+		t.Fatalf("calls=%d", calls.Load())
+	}
+}
+
+func TestTransport_RetriesNetworkErrors(t *testing.T) {
+	stubSleep(t)
+
+	var calls int
+	base := roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		calls++
+		switch {
+		case calls == 1:
+			return nil, &net.OpError{Op: "read", Net: "tcp", Err: syscall.ECONNRESET}
+		case r.URL.Path == "/broken":
+			return nil, errors.New("not a network error")
+		}
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader("")), Header: http.Header{}}, nil
+	})
+
+	cl := New(Options{Retries: 1, Base: base}).Client()
+	resp, err := cl.Get("http://api.test/x")
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	_ = resp.Body.Close()
+	if calls != 2 {
+		t.Fatalf("calls=%d", calls)
+	}
+
+	// Errors from the round tripper itself (not the connection) aren't retried.
+	if _, err := cl.Get("http://api.test/broken"); err == nil || calls != 3 {
+		t.Fatalf("err=%v calls=%d", err, calls)
+	}
+}
+
+func TestTransport_HonorsRetryAfter(t *testing.T) {
+	waits := stubSleep(t)
+
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch calls.Add(1) {
+		case 1:
+			w.Header().Set("Retry-After", "7")
+			w.WriteHeader(http.StatusTooManyRequests)
+		case 2:
+			w.Header().Set("RateLimit-Reset", "3")
+			w.WriteHeader(http.StatusTooManyRequests)
+		default:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer srv.Close()
+
+	resp, err := New(Options{Retries: 3}).Client().Get(srv.URL)
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusNoContent {
+		t.Fatalf("status=%d", resp.StatusCode)
+	}
+	// The hint replaces the backoff and also pauses the host, which has passed by the retry.
+	if len(*waits) != 2 || (*waits)[0] != 7*time.Second || (*waits)[1] != 3*time.Second {
+		t.Fatalf("waits=%v", *waits)
+	}
+}
+
+func TestTransport_RetryAfterBeyondMaxWait(t *testing.T) {
+	stubSleep(t)
+
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Header().Set("Retry-After", "3600")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer srv.Close()
+
+	resp, err := New(Options{Retries: 3, MaxWait: time.Minute}).Client().Get(srv.URL)
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusTooManyRequests || calls.Load() != 1 {
+		t.Fatalf("status=%d calls=%d", resp.StatusCode, calls.Load())
+	}
+}
+
+func TestTransport_HostBudget(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+	}))
+	defer srv.Close()
+
+	// 20 req/s with a burst of 2: the 2 extra requests need ~100ms of budget.
+	cl := New(Options{Rate: 20, Burst: 2}).Client()
+	start := time.Now()
+	for range 4 {
+		resp, err := cl.Get(srv.URL)
+		if err != nil {
+			t.Fatalf("get: %v", err)
+		}
+		_ = resp.Body.Close()
+	}
+	if el := time.Since(start); el < 80*time.Millisecond {
+		t.Fatalf("budget not enforced: 4 requests in %v", el)
+	}
+	if calls.Load() != 4 {
+		t.Fatalf("calls=%d", calls.Load())
+	}
+}
+
+func TestTransport_ContextCanceled(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer srv.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL, nil)
+	if _, err := New(Options{Retries: 3}).Client().Do(req); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+}
+
+func TestServerWait(t *testing.T) {
+	t.Parallel()
+
+	n := time.Date(2025, 12, 20, 12, 0, 0, 0, time.UTC)
+	for _, tc := range []struct {
+		hdr  map[string]string
+		want time.Duration
+		ok   bool
+	}{
+		{map[string]string{"Retry-After": "5"}, 5 * time.Second, true},
+		{map[string]string{"Retry-After": "Sat, 20 Dec 2025 12:00:30 GMT"}, 30 * time.Second, true},
+		{map[string]string{"RateLimit-Reset": "12"}, 12 * time.Second, true},
+		{map[string]string{"X-RateLimit-Reset": "1766232060"}, time.Minute, true},
+		{map[string]string{"Retry-After": "2", "RateLimit-Reset": "40"}, 2 * time.Second, true},
+		{map[string]string{"RateLimit-Reset": "soon"}, 0, false},
+		{nil, 0, false},
+	} {
+		hdr := http.Header{}
+		for k, v := range tc.hdr {
+			hdr.Set(k, v)
+		}
+		got, ok := serverWait(hdr, n)
+		if got != tc.want || ok != tc.ok {
+			t.Fatalf("serverWait(%v)=%v,%t want %v,%t", tc.hdr, got, ok, tc.want, tc.ok)
+		}
+	}
+}
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) { return f(r) }
@@ -0,0 +1,184 @@
+package watch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"runtime"
+	"sync"
+	"time"
+)
+
+// Sink receives order events.
+type Sink interface {
+	Send(ctx context.Context, ev Event) error
+}
+
+// Multi fans an event out to every sink; one failing sink doesn't stop the others.
+type Multi []Sink
+
+func (m Multi) Send(ctx context.Context, ev Event) error {
+	var errs []error
+	for _, s := range m {
+		if err := s.Send(ctx, ev); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// WriterSink prints one line per event (tab-separated, or JSON Lines). Text lines of orders
+// with a known ETA end in an extra "eta 12:45 (in 12m)" column.
+type WriterSink struct {
+	W    io.Writer
+	JSON bool
+}
+
+func (s WriterSink) Send(_ context.Context, ev Event) error {
+	if s.JSON {
+		return json.NewEncoder(s.W).Encode(ev)
+	}
+	line := fmt.Sprintf("%s\t%s\t%s\t%s\t%s\t%s",
+		ev.Time.In(time.Local).Format(time.RFC3339), ev.Kind, ev.Provider, ev.OrderCode, ev.Vendor, ev.Message())
+	if !ev.ETA.IsZero() {
+		line += fmt.Sprintf("\teta %s (%s)", ev.ETA.In(time.Local).Format("15:04"), Countdown(ev.ETA, ev.Time))
+	}
+	_, err := fmt.Fprintln(s.W, line)
+	return err
+}
+
+// CommandSink runs a shell command per event, e.g. for desktop notifications:
+//
+//	notify-send "$ORDERCLI_TITLE" "$ORDERCLI_MESSAGE"
+//
+// The event is passed as ORDERCLI_* environment variables and as JSON on stdin.
+type CommandSink struct {
+	Command string
+	// Timeout bounds a single run (default 10s).
+	Timeout time.Duration
+}
+
+func (s CommandSink) Send(ctx context.Context, ev Event) error {
+	timeout := s.Timeout
+	if timeout <= 0 {
+		timeout = 10 * time.Second
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "cmd", "/C", s.Command) //nolint:gosec
+	} else {
+		cmd = exec.CommandContext(ctx, "sh", "-c", s.Command) //nolint:gosec
+	}
+	b, err := json.Marshal(ev)
+	if err != nil {
+		return err
+	}
+	cmd.Stdin = bytes.NewReader(b)
+	cmd.Env = append(os.Environ(),
+		"ORDERCLI_EVENT="+string(ev.Kind),
+		"ORDERCLI_PROVIDER="+ev.Provider,
+		"ORDERCLI_ORDER="+ev.OrderCode,
+		"ORDERCLI_VENDOR="+ev.Vendor,
+		"ORDERCLI_OLD_STATUS="+ev.OldStatus,
+		"ORDERCLI_STATUS="+ev.NewStatus,
+		"ORDERCLI_STAGE="+string(ev.Stage),
+		"ORDERCLI_TITLE="+ev.Title(),
+		"ORDERCLI_MESSAGE="+ev.Message(),
+	)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("notify command: %w: %s", err, bytes.TrimSpace(out))
+	}
+	return nil
+}
+
+// Queue delivers events to a slow sink (a webhook retrying a dead endpoint can take a
+// minute) in the background, in order, so polling doesn't stall. At most size events
+// wait; further ones are dropped with an error (and dead-lettered, for a WebhookSink).
+// Delivery errors are reported by the next Send, or by Close.
+type Queue struct {
+	sink   Sink
+	ch     chan Event
+	ctx    context.Context
+	cancel context.CancelFunc
+	done   chan struct{}
+
+	mu   sync.Mutex
+	errs []error
+}
+
+// NewQueue starts delivering to sink until ctx ends or Close is called.
+func NewQueue(ctx context.Context, sink Sink, size int) *Queue {
+	if size <= 0 {
+		size = 100
+	}
+	q := &Queue{sink: sink, ch: make(chan Event, size), done: make(chan struct{})}
+	q.ctx, q.cancel = context.WithCancel(ctx)
+	go q.run()
+	return q
+}
+
+func (q *Queue) run() {
+	defer close(q.done)
+	for ev := range q.ch {
+		if err := q.sink.Send(q.ctx, ev); err != nil {
+			q.fail(err)
+		}
+	}
+}
+
+// Send enqueues ev without waiting for it to be delivered; it must not be called after Close.
+func (q *Queue) Send(_ context.Context, ev Event) error {
+	select {
+	case q.ch <- ev:
+	default:
+		err := fmt.Errorf("queue full: dropped %s event for %s", ev.Kind, ev.OrderCode)
+		if dl, ok := q.sink.(deadLetterer); ok {
+			if dlErr := dl.deadLetter(ev, 0, err); dlErr != nil {
+				err = errors.Join(err, dlErr)
+			}
+		}
+		q.fail(err)
+	}
+	return q.takeErrs()
+}
+
+// Close delivers the queued events. Once ctx ends, deliveries still pending are cancelled
+// (a WebhookSink moves them to its dead-letter file).
+func (q *Queue) Close(ctx context.Context) error {
+	close(q.ch)
+	select {
+	case <-q.done:
+	case <-ctx.Done():
+		q.cancel()
+		<-q.done
+	}
+	q.cancel()
+	return q.takeErrs()
+}
+
+// deadLetterer is a sink that keeps events it could not deliver (WebhookSink).
+type deadLetterer interface {
+	deadLetter(ev Event, attempts int, cause error) error
+}
+
+func (q *Queue) fail(err error) {
+	q.mu.Lock()
+	q.errs = append(q.errs, err)
+	q.mu.Unlock()
+}
+
+func (q *Queue) takeErrs() error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	err := errors.Join(q.errs...)
+	q.errs = nil
+	return err
+}
@@ -0,0 +1,186 @@
+package watch
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/steipete/ordercli/internal/provider"
+)
+
+// Broadcaster fans the results of one poller out to any number of Server-Sent Events clients.
+//
+// Every poll is published as an "orders" event (the full active list, also sent to clients
+// when they connect); status changes arrive as "new", "status" and "delivered" events, the
+// same kinds the other sinks see.
+type Broadcaster struct {
+	// Heartbeat is how often idle connections get a comment line (default 15s).
+	Heartbeat time.Duration
+
+	mu       sync.Mutex
+	clients  map[chan sseMessage]struct{}
+	snapshot *sseMessage
+	nextID   int
+	closed   bool
+}
+
+type sseMessage struct {
+	id    int
+	event string
+	data  []byte
+}
+
+// sseClientBuffer is how many messages a client may lag behind before it is disconnected.
+const sseClientBuffer = 32
+
+func NewBroadcaster() *Broadcaster {
+	return &Broadcaster{clients: map[chan sseMessage]struct{}{}}
+}
+
+// Snapshot publishes the current active orders.
+func (b *Broadcaster) Snapshot(orders []provider.Order, pollInterval time.Duration, now time.Time) error {
+	if orders == nil {
+		orders = []provider.Order{}
+	}
+	data, err := json.Marshal(struct {
+		Time      time.Time        `json:"time"`
+		Orders    []provider.Order `json:"orders"`
+		PollInSec int              `json:"poll_in_sec,omitempty"`
+	}{now, orders, int(pollInterval / time.Second)})
+	if err != nil {
+		return err
+	}
+	b.publish("orders", data, true)
+	return nil
+}
+
+// Fail publishes a failed poll as an "error" event; clients keep the last snapshot.
+func (b *Broadcaster) Fail(err error, now time.Time) {
+	data, _ := json.Marshal(map[string]any{"time": now, "error": err.Error()})
+	b.publish("error", data, false)
+}
+
+// Send implements Sink.
+func (b *Broadcaster) Send(_ context.Context, ev Event) error {
+	data, err := json.Marshal(ev)
+	if err != nil {
+		return err
+	}
+	b.publish(string(ev.Kind), data, false)
+	return nil
+}
+
+// Clients returns the number of connected clients.
+func (b *Broadcaster) Clients() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return len(b.clients)
+}
+
+func (b *Broadcaster) publish(event string, data []byte, snapshot bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.nextID++
+	msg := sseMessage{id: b.nextID, event: event, data: data}
+	if snapshot {
+		b.snapshot = &msg
+	}
+	for ch := range b.clients {
+		select {
+		case ch <- msg:
+		default:
+			// Too slow: drop the client rather than stall the poller. It will reconnect
+			// and start over from the latest snapshot.
+			delete(b.clients, ch)
+			close(ch)
+		}
+	}
+}
+
+func (b *Broadcaster) subscribe() (chan sseMessage, *sseMessage) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	ch := make(chan sseMessage, sseClientBuffer)
+	if b.closed {
+		close(ch)
+		return ch, nil
+	}
+	b.clients[ch] = struct{}{}
+	return ch, b.snapshot
+}
+
+// Close ends all streams (http.Server.Shutdown doesn't interrupt running handlers).
+func (b *Broadcaster) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.closed = true
+	for ch := range b.clients {
+		delete(b.clients, ch)
+		close(ch)
+	}
+}
+
+func (b *Broadcaster) unsubscribe(ch chan sseMessage) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if _, ok := b.clients[ch]; ok {
+		delete(b.clients, ch)
+		close(ch)
+	}
+}
+
+// ServeHTTP streams events to one client until it disconnects.
+func (b *Broadcaster) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	rc := http.NewResponseController(w)
+	h := w.Header()
+	h.Set("Content-Type", "text/event-stream")
+	h.Set("Cache-Control", "no-cache")
+	h.Set("Connection", "keep-alive")
+	h.Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	ch, snapshot := b.subscribe()
+	defer b.unsubscribe(ch)
+
+	if snapshot != nil {
+		writeSSE(w, *snapshot)
+	} else {
+		fmt.Fprint(w, ": waiting for first poll\n\n")
+	}
+	if err := rc.Flush(); err != nil {
+		return
+	}
+
+	heartbeat := b.Heartbeat
+	if heartbeat <= 0 {
+		heartbeat = 15 * time.Second
+	}
+	tick := time.NewTicker(heartbeat)
+	defer tick.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case msg, ok := <-ch:
+			if !ok {
+				return
+			}
+			writeSSE(w, msg)
+		case <-tick.C:
+			fmt.Fprint(w, ": ping\n\n")
+		}
+		if err := rc.Flush(); err != nil {
+			return
+		}
+	}
+}
+
+// writeSSE writes one message; data is single-line JSON, so it needs no splitting.
+func writeSSE(w http.ResponseWriter, msg sseMessage) {
+	fmt.Fprintf(w, "id: %s\nevent: %s\ndata: %s\n\n", strconv.Itoa(msg.id), msg.event, msg.data)
+}
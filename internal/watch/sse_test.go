@@ -0,0 +1,122 @@
+package watch
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steipete/ordercli/internal/provider"
+)
+
+// readSSE returns the next non-comment message as "event data".
+func readSSE(t *testing.T, r *bufio.Reader) string {
+	t.Helper()
+	var event, data string
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			t.Fatalf("read: %v", err)
+		}
+		line = strings.TrimRight(line, "\n")
+		switch {
+		case line == "" && event != "":
+			return event + " " + data
+		case strings.HasPrefix(line, "event: "):
+			event = strings.TrimPrefix(line, "event: ")
+		case strings.HasPrefix(line, "data: "):
+			data = strings.TrimPrefix(line, "data: ")
+		}
+	}
+}
+
+func connectSSE(t *testing.T, url string) *bufio.Reader {
+	t.Helper()
+	resp, err := http.Get(url)
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	t.Cleanup(func() { _ = resp.Body.Close() })
+	if ct := resp.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Fatalf("content-type=%q", ct)
+	}
+	return bufio.NewReader(resp.Body)
+}
+
+func waitClients(t *testing.T, b *Broadcaster, n int) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for b.Clients() != n {
+		if time.Now().After(deadline) {
+			t.Fatalf("clients=%d, want %d", b.Clients(), n)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestBroadcaster(t *testing.T) {
+	t.Parallel()
+
+	b := NewBroadcaster()
+	srv := httptest.NewServer(b)
+	defer srv.Close()
+	defer b.Close()
+
+	now := time.Date(2025, 12, 20, 12, 0, 0, 0, time.UTC)
+	orders := []provider.Order{{Provider: "foodora", ID: "OC-1", Vendor: provider.Vendor{Name: "Pizza"}, Status: "Cooking", Active: true}}
+	if err := b.Snapshot(orders, 20*time.Second, now); err != nil {
+		t.Fatalf("snapshot: %v", err)
+	}
+
+	// Both clients start from the latest snapshot and then share every published message.
+	c1 := connectSSE(t, srv.URL)
+	c2 := connectSSE(t, srv.URL)
+	for _, c := range []*bufio.Reader{c1, c2} {
+		got := readSSE(t, c)
+		if !strings.HasPrefix(got, "orders ") || !strings.Contains(got, `"id":"OC-1"`) || !strings.Contains(got, `"poll_in_sec":20`) {
+			t.Fatalf("snapshot=%q", got)
+		}
+	}
+	waitClients(t, b, 2)
+
+	ev := Event{Time: now, Kind: KindStatus, Provider: "foodora", OrderCode: "OC-1", OldStatus: "Cooking", NewStatus: "On the way", Stage: StagePickedUp}
+	if err := b.Send(context.Background(), ev); err != nil {
+		t.Fatalf("send: %v", err)
+	}
+	b.Fail(errors.New("upstream down"), now)
+	for _, c := range []*bufio.Reader{c1, c2} {
+		if got := readSSE(t, c); !strings.HasPrefix(got, "status ") || !strings.Contains(got, `"new_status":"On the way"`) {
+			t.Fatalf("event=%q", got)
+		}
+		if got := readSSE(t, c); !strings.HasPrefix(got, "error ") || !strings.Contains(got, "upstream down") {
+			t.Fatalf("error=%q", got)
+		}
+	}
+
+	b.Close()
+	waitClients(t, b, 0)
+}
+
+func TestBroadcaster_DropsSlowClients(t *testing.T) {
+	t.Parallel()
+
+	b := NewBroadcaster()
+	ch, _ := b.subscribe()
+	for range sseClientBuffer + 1 {
+		b.Fail(errors.New("x"), time.Now())
+	}
+	if b.Clients() != 0 {
+		t.Fatalf("slow client still subscribed")
+	}
+	n := 0
+	for range ch {
+		n++
+	}
+	if n != sseClientBuffer {
+		t.Fatalf("buffered=%d", n)
+	}
+}
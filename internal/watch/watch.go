@@ -0,0 +1,227 @@
+package watch
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/steipete/ordercli/internal/provider"
+)
+
+type Kind string
+
+const (
+	// KindNew is an order that showed up after the first poll.
+	KindNew       Kind = "new"
+	KindStatus    Kind = "status"
+	KindDelivered Kind = "delivered"
+	// KindCancelled is an order the provider reports as cancelled, rejected or failed.
+	KindCancelled Kind = "cancelled"
+	// KindGone is an order that dropped out of the active list without a final status; it
+	// may have been delivered or cancelled.
+	KindGone Kind = "gone"
+)
+
+// Final reports whether the order is no longer being watched after this event.
+func (k Kind) Final() bool {
+	return k == KindDelivered || k == KindCancelled || k == KindGone
+}
+
+// Stage is a coarse, provider-neutral reading of the status text.
+type Stage string
+
+const (
+	StageAccepted  Stage = "accepted"
+	StagePreparing Stage = "preparing"
+	StagePickedUp  Stage = "picked_up"
+	StageDelivered Stage = "delivered"
+)
+
+type Event struct {
+	Time      time.Time `json:"time"`
+	Kind      Kind      `json:"kind"`
+	Provider  string    `json:"provider"`
+	OrderCode string    `json:"order_code"`
+	Vendor    string    `json:"vendor,omitempty"`
+	OldStatus string    `json:"old_status,omitempty"`
+	NewStatus string    `json:"new_status,omitempty"`
+	Stage     Stage     `json:"stage,omitempty"`
+	ETA       time.Time `json:"eta,omitzero"`
+}
+
+// Title and Message are short texts for notifications.
+func (e Event) Title() string {
+	if e.Vendor != "" {
+		return e.Vendor
+	}
+	return e.Provider + " order " + e.OrderCode
+}
+
+func (e Event) Message() string {
+	switch {
+	case e.Kind == KindGone:
+		return "no longer active"
+	case e.Kind.Final() && e.NewStatus == "":
+		return string(e.Kind)
+	case e.OldStatus != "" && e.NewStatus != "":
+		return e.OldStatus + " -> " + e.NewStatus
+	case e.NewStatus != "":
+		return e.NewStatus
+	default:
+		return string(e.Kind)
+	}
+}
+
+var stageKeywords = []struct {
+	stage Stage
+	words []string
+}{
+	// Checked in order: "picked up" beats "prepared", "delivered" beats everything.
+	{StageDelivered, []string{"delivered", "enjoy", "arrived", "zugestellt", "geliefert"}},
+	{StagePickedUp, []string{"picked", "on the way", "on its way", "out for delivery", "unterwegs", "abgeholt"}},
+	{StagePreparing, []string{"prepar", "cooking", "kitchen", "zubereit"}},
+	{StageAccepted, []string{"accept", "confirm", "received", "placed", "bestätigt", "angenommen"}},
+}
+
+// StageOf maps a status text onto a Stage; empty when unknown.
+func StageOf(status string) Stage {
+	s := strings.ToLower(status)
+	for _, k := range stageKeywords {
+		for _, w := range k.words {
+			if strings.Contains(s, w) {
+				return k.stage
+			}
+		}
+	}
+	return ""
+}
+
+// Countdown describes how far away eta is: "in 12m", "in 1h05m", "due now" or "4m late".
+// Empty without an ETA.
+func Countdown(eta, now time.Time) string {
+	if eta.IsZero() {
+		return ""
+	}
+	d := eta.Sub(now).Round(time.Minute)
+	switch {
+	case d == 0:
+		return "due now"
+	case d < 0:
+		return shortDuration(-d) + " late"
+	default:
+		return "in " + shortDuration(d)
+	}
+}
+
+func shortDuration(d time.Duration) string {
+	h, m := int(d/time.Hour), int(d%time.Hour/time.Minute)
+	if h > 0 {
+		return fmt.Sprintf("%dh%02dm", h, m)
+	}
+	return fmt.Sprintf("%dm", m)
+}
+
+type tracked struct {
+	order provider.Order
+	done  bool
+}
+
+// Tracker diffs successive active-order snapshots. Orders that are no longer active are
+// delivered or cancelled; orders that dropped out of the list are gone.
+type Tracker struct {
+	orders map[string]*tracked
+	keys   []string
+	polls  int
+}
+
+func NewTracker() *Tracker {
+	return &Tracker{orders: map[string]*tracked{}}
+}
+
+func key(o provider.Order) string { return o.Provider + "\x00" + o.ID }
+
+// Update records a snapshot and returns the transitions since the previous one. The first
+// snapshot only establishes the baseline.
+func (t *Tracker) Update(orders []provider.Order, now time.Time) []Event {
+	first := t.polls == 0
+	t.polls++
+
+	var events []Event
+	seen := map[string]bool{}
+	for _, o := range orders {
+		k := key(o)
+		seen[k] = true
+		prev, ok := t.orders[k]
+		if !ok {
+			prev = &tracked{order: o, done: !o.Active}
+			t.orders[k] = prev
+			t.keys = append(t.keys, k)
+			if !first && o.Active {
+				events = append(events, newEvent(KindNew, o, "", now))
+			}
+			continue
+		}
+		old := prev.order
+		prev.order = o
+		if prev.done {
+			continue
+		}
+		if !o.Active {
+			prev.done = true
+			kind := KindDelivered
+			if o.Cancelled {
+				kind = KindCancelled
+			}
+			events = append(events, newEvent(kind, o, old.Status, now))
+			continue
+		}
+		if o.Status != old.Status {
+			events = append(events, newEvent(KindStatus, o, old.Status, now))
+		}
+	}
+
+	for _, k := range t.keys {
+		tr := t.orders[k]
+		if seen[k] || tr.done {
+			continue
+		}
+		tr.done = true
+		ev := newEvent(KindGone, tr.order, tr.order.Status, now)
+		ev.NewStatus = ""
+		events = append(events, ev)
+	}
+	return events
+}
+
+func newEvent(kind Kind, o provider.Order, oldStatus string, now time.Time) Event {
+	ev := Event{
+		Time:      now,
+		Kind:      kind,
+		Provider:  o.Provider,
+		OrderCode: o.ID,
+		Vendor:    o.Vendor.Name,
+		OldStatus: oldStatus,
+		NewStatus: o.Status,
+		Stage:     StageOf(o.Status),
+		ETA:       o.ETA,
+	}
+	if kind.Final() {
+		ev.Stage = ""
+		ev.ETA = time.Time{}
+	}
+	if kind == KindDelivered {
+		ev.Stage = StageDelivered
+	}
+	return ev
+}
+
+// Pending is the number of tracked orders still active (not delivered, cancelled or gone).
+func (t *Tracker) Pending() int {
+	n := 0
+	for _, tr := range t.orders {
+		if !tr.done {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,152 @@
+package watch
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steipete/ordercli/internal/provider"
+)
+
+func order(id, status string) provider.Order {
+	return provider.Order{Provider: "foodora", ID: id, Vendor: provider.Vendor{Name: "V"}, Status: status, Active: true}
+}
+
+func TestTracker(t *testing.T) {
+	t.Parallel()
+	tr := NewTracker()
+	now := time.Date(2025, 12, 20, 18, 0, 0, 0, time.UTC)
+
+	if ev := tr.Update([]provider.Order{order("A", "Order accepted")}, now); len(ev) != 0 {
+		t.Fatalf("baseline should not emit: %#v", ev)
+	}
+	if ev := tr.Update([]provider.Order{order("A", "Order accepted")}, now); len(ev) != 0 {
+		t.Fatalf("unchanged should not emit: %#v", ev)
+	}
+
+	ev := tr.Update([]provider.Order{order("A", "Preparing your food"), order("B", "Order accepted")}, now)
+	if len(ev) != 2 || ev[0].Kind != KindStatus || ev[0].OldStatus != "Order accepted" || ev[0].Stage != StagePreparing || ev[1].Kind != KindNew || ev[1].OrderCode != "B" {
+		t.Fatalf("unexpected: %#v", ev)
+	}
+	if tr.Pending() != 2 {
+		t.Fatalf("pending=%d", tr.Pending())
+	}
+
+	delivered := order("B", "Delivered")
+	delivered.Active = false
+	ev = tr.Update([]provider.Order{delivered}, now)
+	// A dropped-out order isn't assumed delivered.
+	if len(ev) != 2 || ev[0].OrderCode != "B" || ev[0].Kind != KindDelivered || ev[0].Stage != StageDelivered ||
+		ev[1].OrderCode != "A" || ev[1].Kind != KindGone || ev[1].Stage != "" || ev[1].Message() != "no longer active" {
+		t.Fatalf("unexpected: %#v", ev)
+	}
+	if tr.Pending() != 0 {
+		t.Fatalf("pending=%d", tr.Pending())
+	}
+	if ev := tr.Update(nil, now); len(ev) != 0 {
+		t.Fatalf("finished orders must not repeat: %#v", ev)
+	}
+
+	tr.Update([]provider.Order{order("C", "Order accepted")}, now)
+	cancelled := order("C", "Storniert")
+	cancelled.Active, cancelled.Cancelled = false, true
+	ev = tr.Update([]provider.Order{cancelled}, now)
+	if len(ev) != 1 || ev[0].Kind != KindCancelled || ev[0].Stage != "" || tr.Pending() != 0 {
+		t.Fatalf("unexpected: %#v", ev)
+	}
+}
+
+func TestStageOf(t *testing.T) {
+	t.Parallel()
+	for in, want := range map[string]Stage{
+		"Order received":                 StageAccepted,
+		"The restaurant is cooking":      StagePreparing,
+		"Your rider picked up the order": StagePickedUp,
+		"Out for delivery":               StagePickedUp,
+		"Delivered":                      StageDelivered,
+		"???":                            "",
+	} {
+		if got := StageOf(in); got != want {
+			t.Fatalf("%q: got %q want %q", in, got, want)
+		}
+	}
+}
+
+func TestWriterSink(t *testing.T) {
+	t.Parallel()
+	var buf bytes.Buffer
+	ev := Event{Time: time.Unix(0, 0), Kind: KindStatus, Provider: "foodora", OrderCode: "A", Vendor: "V", OldStatus: "x", NewStatus: "y"}
+	if err := (WriterSink{W: &buf}).Send(context.Background(), ev); err != nil {
+		t.Fatalf("send: %v", err)
+	}
+	if !strings.HasSuffix(buf.String(), "\tstatus\tfoodora\tA\tV\tx -> y\n") {
+		t.Fatalf("unexpected: %q", buf.String())
+	}
+	buf.Reset()
+	if err := (WriterSink{W: &buf, JSON: true}).Send(context.Background(), ev); err != nil {
+		t.Fatalf("send: %v", err)
+	}
+	if !strings.Contains(buf.String(), `"order_code":"A"`) || !strings.Contains(buf.String(), `"old_status":"x"`) {
+		t.Fatalf("unexpected: %q", buf.String())
+	}
+}
+
+func TestWriterSink_ETA(t *testing.T) {
+	t.Parallel()
+	var buf bytes.Buffer
+	now := time.Date(2025, 12, 20, 12, 0, 0, 0, time.Local)
+	ev := Event{Time: now, Kind: KindStatus, Provider: "deliveroo", OrderCode: "A", NewStatus: "preparing", ETA: now.Add(12 * time.Minute)}
+	if err := (WriterSink{W: &buf}).Send(context.Background(), ev); err != nil {
+		t.Fatalf("send: %v", err)
+	}
+	if !strings.HasSuffix(buf.String(), "\tpreparing\teta 12:12 (in 12m)\n") {
+		t.Fatalf("unexpected: %q", buf.String())
+	}
+}
+
+func TestCountdown(t *testing.T) {
+	t.Parallel()
+	now := time.Date(2025, 12, 20, 12, 0, 0, 0, time.UTC)
+	for _, tc := range []struct {
+		eta  time.Time
+		want string
+	}{
+		{time.Time{}, ""},
+		{now.Add(12*time.Minute + 20*time.Second), "in 12m"},
+		{now.Add(65 * time.Minute), "in 1h05m"},
+		{now.Add(20 * time.Second), "due now"},
+		{now.Add(-4 * time.Minute), "4m late"},
+	} {
+		if got := Countdown(tc.eta, now); got != tc.want {
+			t.Fatalf("Countdown(%v)=%q want %q", tc.eta, got, tc.want)
+		}
+	}
+}
+
+func TestCommandSink(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses sh")
+	}
+	t.Parallel()
+	out := filepath.Join(t.TempDir(), "out.txt")
+	s := CommandSink{Command: `printf '%s|%s|' "$ORDERCLI_ORDER" "$ORDERCLI_MESSAGE" > "` + out + `"; cat >> "` + out + `"`}
+	if err := s.Send(context.Background(), Event{Kind: KindStatus, OrderCode: "A", NewStatus: "Cooking"}); err != nil {
+		t.Fatalf("send: %v", err)
+	}
+	b, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	if !strings.HasPrefix(string(b), "A|Cooking|{") {
+		t.Fatalf("unexpected: %q", b)
+	}
+
+	if err := (CommandSink{Command: "echo boom >&2; exit 3"}).Send(context.Background(), Event{}); err == nil || !strings.Contains(err.Error(), "boom") {
+		t.Fatalf("expected error with output, got %v", err)
+	}
+}
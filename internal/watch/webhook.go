@@ -0,0 +1,179 @@
+package watch
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/steipete/ordercli/internal/version"
+)
+
+const (
+	SignatureHeader = "X-Ordercli-Signature"
+	EventHeader     = "X-Ordercli-Event"
+	DeliveryHeader  = "X-Ordercli-Delivery"
+)
+
+// WebhookSink POSTs events as JSON. With a Secret, the body is signed with HMAC-SHA256 and sent
+// as "sha256=<hex>" in X-Ordercli-Signature. Network errors, 429 and 5xx are retried with
+// exponential backoff; events that still fail are appended to DeadLetter (JSON Lines).
+type WebhookSink struct {
+	URL    string
+	Secret string
+	Client *http.Client
+
+	// MaxAttempts includes the first try (default 4).
+	MaxAttempts int
+	// Backoff is the delay before the first retry, doubled per attempt (default 1s).
+	Backoff    time.Duration
+	DeadLetter string
+}
+
+// DeadLetterEntry is one line of the dead-letter file.
+type DeadLetterEntry struct {
+	FailedAt time.Time `json:"failed_at"`
+	URL      string    `json:"url"`
+	Attempts int       `json:"attempts"`
+	Error    string    `json:"error"`
+	Event    Event     `json:"event"`
+}
+
+func Sign(secret string, body []byte) string {
+	m := hmac.New(sha256.New, []byte(secret))
+	_, _ = m.Write(body)
+	return "sha256=" + hex.EncodeToString(m.Sum(nil))
+}
+
+// VerifySignature checks a X-Ordercli-Signature header value in constant time.
+func VerifySignature(secret string, body []byte, header string) bool {
+	return hmac.Equal([]byte(Sign(secret, body)), []byte(header))
+}
+
+func (s WebhookSink) Send(ctx context.Context, ev Event) error {
+	body, err := json.Marshal(ev)
+	if err != nil {
+		return err
+	}
+	attempts := s.MaxAttempts
+	if attempts <= 0 {
+		attempts = 4
+	}
+	backoff := s.Backoff
+	if backoff <= 0 {
+		backoff = time.Second
+	}
+	delivery := strconv.FormatInt(time.Now().UnixNano(), 36)
+
+	var lastErr error
+	n := 0
+	for n < attempts {
+		if n > 0 {
+			if err := sleep(ctx, backoff<<(n-1)); err != nil {
+				lastErr = err
+				break
+			}
+		}
+		n++
+		retry, err := s.post(ctx, body, ev, delivery)
+		if err == nil {
+			return nil
+		}
+		lastErr = err
+		if !retry {
+			break
+		}
+	}
+
+	err = fmt.Errorf("webhook %s: giving up after %d attempt(s): %w", ev.OrderCode, n, lastErr)
+	if dlErr := s.deadLetter(ev, n, lastErr); dlErr != nil {
+		return errors.Join(err, dlErr)
+	}
+	return err
+}
+
+// deadLetter appends ev to the DeadLetter file, if any, after attempts failed deliveries.
+func (s WebhookSink) deadLetter(ev Event, attempts int, cause error) error {
+	if s.DeadLetter == "" {
+		return nil
+	}
+	return s.writeDeadLetter(DeadLetterEntry{
+		FailedAt: time.Now().UTC(),
+		URL:      s.URL,
+		Attempts: attempts,
+		Error:    cause.Error(),
+		Event:    ev,
+	})
+}
+
+// post makes one delivery attempt; retry reports whether the failure is worth retrying.
+func (s WebhookSink) post(ctx context.Context, body []byte, ev Event, delivery string) (retry bool, err error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.URL, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "ordercli/"+version.Version)
+	req.Header.Set(EventHeader, string(ev.Kind))
+	req.Header.Set(DeliveryHeader, delivery)
+	if s.Secret != "" {
+		req.Header.Set(SignatureHeader, Sign(s.Secret, body))
+	}
+
+	c := s.Client
+	if c == nil {
+		c = &http.Client{Timeout: 15 * time.Second}
+	}
+	resp, err := c.Do(req)
+	if err != nil {
+		return ctx.Err() == nil, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return false, nil
+	}
+	retry = resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+	return retry, fmt.Errorf("HTTP %d", resp.StatusCode)
+}
+
+func (s WebhookSink) writeDeadLetter(e DeadLetterEntry) error {
+	b, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(s.DeadLetter), 0o700); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(s.DeadLetter, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(b, '\n')); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
@@ -0,0 +1,146 @@
+package watch
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWebhookSink_SignsAndRetries(t *testing.T) {
+	t.Parallel()
+	var calls atomic.Int32
+	var mu sync.Mutex
+	var deliveries []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if !VerifySignature("s3cret", body, r.Header.Get(SignatureHeader)) {
+			t.Errorf("bad signature %q", r.Header.Get(SignatureHeader))
+		}
+		if r.Header.Get(EventHeader) != "status" {
+			t.Errorf("event=%q", r.Header.Get(EventHeader))
+		}
+		mu.Lock()
+		deliveries = append(deliveries, r.Header.Get(DeliveryHeader))
+		mu.Unlock()
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		var ev Event
+		if err := json.Unmarshal(body, &ev); err != nil || ev.OrderCode != "A" || ev.OldStatus != "x" || ev.NewStatus != "y" {
+			t.Errorf("payload: %v %s", err, body)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	s := WebhookSink{URL: srv.URL, Secret: "s3cret", Backoff: time.Millisecond}
+	if err := s.Send(context.Background(), Event{Kind: KindStatus, Provider: "foodora", OrderCode: "A", OldStatus: "x", NewStatus: "y"}); err != nil {
+		t.Fatalf("send: %v", err)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if calls.Load() != 3 || deliveries[0] == "" || deliveries[0] != deliveries[2] {
+		t.Fatalf("calls=%d deliveries=%v", calls.Load(), deliveries)
+	}
+}
+
+func TestWebhookSink_DeadLetter(t *testing.T) {
+	t.Parallel()
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer srv.Close()
+
+	dl := filepath.Join(t.TempDir(), "sub", "dead.jsonl")
+	s := WebhookSink{URL: srv.URL, Backoff: time.Millisecond, DeadLetter: dl}
+	for range 2 {
+		if err := s.Send(context.Background(), Event{Kind: KindDelivered, OrderCode: "B"}); err == nil || !strings.Contains(err.Error(), "HTTP 400") {
+			t.Fatalf("expected error, got %v", err)
+		}
+	}
+	if calls.Load() != 2 {
+		t.Fatalf("4xx must not be retried: calls=%d", calls.Load())
+	}
+
+	b, err := os.ReadFile(dl)
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(b)), "\n")
+	var e DeadLetterEntry
+	if len(lines) != 2 || json.Unmarshal([]byte(lines[0]), &e) != nil || e.Event.OrderCode != "B" || e.Attempts != 1 || e.Error != "HTTP 400" {
+		t.Fatalf("unexpected dead letter: %s", b)
+	}
+}
+
+func TestWebhookSink_NetworkErrorGivesUp(t *testing.T) {
+	t.Parallel()
+	srv := httptest.NewServer(http.NotFoundHandler())
+	url := srv.URL
+	srv.Close()
+
+	s := WebhookSink{URL: url, MaxAttempts: 2, Backoff: time.Millisecond}
+	if err := s.Send(context.Background(), Event{OrderCode: "C"}); err == nil || !strings.Contains(err.Error(), "after 2 attempt(s)") {
+		t.Fatalf("unexpected: %v", err)
+	}
+}
+
+func TestQueue_DoesNotBlockOnSlowWebhook(t *testing.T) {
+	t.Parallel()
+	release := make(chan struct{})
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+	defer close(release)
+
+	dl := filepath.Join(t.TempDir(), "dead.jsonl")
+	q := NewQueue(context.Background(), WebhookSink{URL: srv.URL, Backoff: time.Millisecond, DeadLetter: dl}, 2)
+	start := time.Now()
+	for _, code := range []string{"A", "B", "C", "D"} {
+		err := q.Send(context.Background(), Event{Kind: KindStatus, OrderCode: code})
+		for code == "A" && calls.Load() == 0 {
+			time.Sleep(time.Millisecond)
+		}
+		// A is in flight and B, C wait; D doesn't fit anymore.
+		if code == "D" && (err == nil || !strings.Contains(err.Error(), "dropped status event for D")) {
+			t.Fatalf("expected drop, got %v", err)
+		}
+	}
+	if el := time.Since(start); el > time.Second {
+		t.Fatalf("Send blocked for %v", el)
+	}
+
+	// The dropped event and whatever isn't delivered when Close gives up end up in the
+	// dead-letter file.
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if err := q.Close(ctx); err == nil {
+		t.Fatalf("expected delivery errors")
+	}
+	b, err := os.ReadFile(dl)
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	if n := strings.Count(string(b), "\n"); n != 4 || calls.Load() != 1 || !strings.Contains(string(b), `"attempts":0,"error":"queue full: dropped status event for D"`) {
+		t.Fatalf("dead letters=%d calls=%d:\n%s", n, calls.Load(), b)
+	}
+}